@@ -0,0 +1,23 @@
+data "ansible_ssh_host_keys" "example" {
+  host = "192.0.2.10"
+
+  # wait for a freshly booted machine
+  timeouts = {
+    read = "10m"
+  }
+}
+
+resource "ansible_navigator_run" "example" {
+  playbook = "# example"
+  inventory = provider::ansible::inventory({
+    example = {
+      host = "192.0.2.10"
+      vars = {
+        ansible_ssh_common_args = provider::ansible::ssh_args(false)
+      }
+    }
+  }, null, null)
+  ansible_options = {
+    known_hosts = data.ansible_ssh_host_keys.example.known_hosts
+  }
+}
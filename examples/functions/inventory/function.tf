@@ -0,0 +1,22 @@
+resource "ansible_navigator_run" "example" {
+  playbook = "# example"
+  inventory = provider::ansible::inventory(
+    {
+      web1 = "10.0.0.1"
+      db1 = {
+        host   = "10.0.0.2"
+        port   = 2222
+        user   = "admin"
+        groups = ["db"]
+        vars   = { role = "primary" }
+      }
+    },
+    {
+      web  = { hosts = ["web1"], vars = { http_port = 80 } }
+      prod = { children = ["web", "db"] }
+    },
+    {
+      ansible_ssh_common_args = provider::ansible::ssh_args(true)
+    },
+  )
+}
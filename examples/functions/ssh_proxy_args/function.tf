@@ -0,0 +1,34 @@
+resource "ansible_navigator_run" "bastion" {
+  playbook = "# example"
+  inventory = yamlencode({
+    all = {
+      hosts = {
+        internal = {
+          ansible_host = "10.0.0.10"
+        }
+      }
+      vars = {
+        ansible_ssh_common_args = provider::ansible::ssh_proxy_args(false)
+      }
+    }
+  })
+  ansible_options = {
+    private_keys = [
+      {
+        name = "bastion"
+        data = file("~/.ssh/bastion")
+      },
+    ]
+    ssh_proxy = {
+      host             = "bastion.example.com"
+      user             = "jump"
+      private_key_name = "bastion"
+      known_hosts = [
+        provider::ansible::ssh_known_host("ssh-ed25519 AAAA...", "bastion.example.com"),
+      ]
+    }
+    known_hosts = [
+      provider::ansible::ssh_known_host("ssh-ed25519 AAAA...", "10.0.0.10"),
+    ]
+  }
+}
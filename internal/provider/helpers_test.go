@@ -1,9 +1,17 @@
 package provider_test
 
 import (
+	"bytes"
 	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
 	"maps"
@@ -13,6 +21,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gliderlabs/ssh"
 	"github.com/hashicorp/terraform-plugin-testing/config"
@@ -29,6 +38,7 @@ const (
 	// TODO improve.
 	navigatorProgramPath = "../../.venv/bin/ansible-navigator"
 	playbookProgramPath  = "../../.venv/bin/ansible-playbook"
+	runnerProgramPath    = "../../.venv/bin/ansible-runner"
 	testString           = "testing"
 	testUpdateString     = "testing (update)"
 )
@@ -124,12 +134,24 @@ func testTerraformConfig(t *testing.T, names ...string) string {
 func testSSHKeygen(t *testing.T) (string, string) {
 	t.Helper()
 
+	return testSSHKeygenWithPassphrase(t, "")
+}
+
+func testSSHKeygenWithPassphrase(t *testing.T, passphrase string) (string, string) {
+	t.Helper()
+
 	pub, priv, err := ed25519.GenerateKey(nil)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	privateKey, err := gossh.MarshalPrivateKey(crypto.PrivateKey(priv), "")
+	var privateKey *pem.Block
+	if passphrase == "" {
+		privateKey, err = gossh.MarshalPrivateKey(crypto.PrivateKey(priv), "")
+	} else {
+		privateKey, err = gossh.MarshalPrivateKeyWithPassphrase(crypto.PrivateKey(priv), "", []byte(passphrase))
+	}
+
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -142,25 +164,61 @@ func testSSHKeygen(t *testing.T) (string, string) {
 	return fmt.Sprintf("ssh-ed25519 %s", base64.StdEncoding.EncodeToString(publicKey.Marshal())), string(pem.EncodeToMemory(privateKey))
 }
 
-func testSSHServer(t *testing.T, clientPublicKey string, serverPrivateKey string) int {
+// testVaultEncrypt produces the same envelope as `ansible-vault encrypt_string`
+// (format 1.2 with a vault ID, AES256 cipher).
+func testVaultEncrypt(t *testing.T, vaultID string, password string, plaintext string) string {
 	t.Helper()
 
-	listener, err := net.Listen("tcp", "localhost:0") //nolint:noctx
+	const keyLen, ivLen = 32, aes.BlockSize
+
+	salt := make([]byte, keyLen)
+	if _, err := rand.Read(salt); err != nil {
+		t.Fatal(err)
+	}
+
+	derived, err := pbkdf2.Key(sha256.New, password, salt, 10000, 2*keyLen+ivLen)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	sshServer := ssh.Server{
-		Handler: func(s ssh.Session) {
-			_, err = s.Write([]byte("hello world!"))
-			if err != nil {
-				t.Fatal(err)
-			}
-		},
+	cipherKey, hmacKey, iv := derived[:keyLen], derived[keyLen:2*keyLen], derived[2*keyLen:]
+
+	block, err := aes.NewCipher(cipherKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+	padded := append([]byte(plaintext), bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	ciphertext := make([]byte, len(padded))
+	cipher.NewCTR(block, iv).XORKeyStream(ciphertext, padded)
+
+	mac := hmac.New(sha256.New, hmacKey)
+	mac.Write(ciphertext)
+
+	body := hex.EncodeToString([]byte(strings.Join([]string{
+		hex.EncodeToString(salt),
+		hex.EncodeToString(mac.Sum(nil)),
+		hex.EncodeToString(ciphertext),
+	}, "\n")))
+
+	lines := []string{fmt.Sprintf("$ANSIBLE_VAULT;1.2;AES256;%s", vaultID)}
+	for len(body) > 80 {
+		lines = append(lines, body[:80])
+		body = body[80:]
 	}
 
+	return strings.Join(append(lines, body), "\n")
+}
+
+func testSSHServer(t *testing.T, clientPublicKey string, serverPrivateKey string) int {
+	t.Helper()
+
+	sshServer := &ssh.Server{}
+
 	if clientPublicKey != "" {
-		err = sshServer.SetOption(
+		err := sshServer.SetOption(
 			ssh.PublicKeyAuth(func(_ ssh.Context, key ssh.PublicKey) bool {
 				allowed, _, _, _, err := gossh.ParseAuthorizedKey([]byte(clientPublicKey))
 				if err != nil {
@@ -175,11 +233,131 @@ func testSSHServer(t *testing.T, clientPublicKey string, serverPrivateKey string
 		}
 	}
 
-	err = sshServer.SetOption(ssh.HostKeyPEM([]byte(serverPrivateKey)))
+	err := sshServer.SetOption(ssh.HostKeyPEM([]byte(serverPrivateKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return testServeSSH(t, sshServer)
+}
+
+// testSSHProxyServer only forwards connections for the client, as a bastion
+// would, and does not run sessions.
+func testSSHProxyServer(t *testing.T, clientPublicKey string, serverPrivateKey string) int {
+	t.Helper()
+
+	allowed, _, _, _, err := gossh.ParseAuthorizedKey([]byte(clientPublicKey)) //nolint:dogsled
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sshServer := &ssh.Server{
+		PublicKeyHandler: func(_ ssh.Context, key ssh.PublicKey) bool {
+			return ssh.KeysEqual(key, allowed)
+		},
+		LocalPortForwardingCallback: func(ssh.Context, string, uint32) bool {
+			return true
+		},
+		ChannelHandlers: map[string]ssh.ChannelHandler{
+			"direct-tcpip": ssh.DirectTCPIPHandler,
+		},
+	}
+
+	if err := sshServer.SetOption(ssh.HostKeyPEM([]byte(serverPrivateKey))); err != nil {
+		t.Fatal(err)
+	}
+
+	return testServeSSH(t, sshServer)
+}
+
+// testSSHCertificateServer only accepts client certificates signed by the
+// certificate authority, and presents a host certificate signed by it.
+func testSSHCertificateServer(t *testing.T, caPrivateKey string, serverPrivateKey string) int {
+	t.Helper()
+
+	caSigner, err := gossh.ParsePrivateKey([]byte(caPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverSigner, err := gossh.ParsePrivateKey([]byte(serverPrivateKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hostCert := &gossh.Certificate{
+		Key:         serverSigner.PublicKey(),
+		CertType:    gossh.HostCert,
+		ValidBefore: gossh.CertTimeInfinity,
+	}
+
+	if err := hostCert.SignCert(rand.Reader, caSigner); err != nil {
+		t.Fatal(err)
+	}
+
+	hostCertSigner, err := gossh.NewCertSigner(hostCert, serverSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sshServer := &ssh.Server{
+		PublicKeyHandler: func(ctx ssh.Context, key ssh.PublicKey) bool {
+			cert, ok := key.(*gossh.Certificate)
+			if !ok || cert.CertType != gossh.UserCert || !ssh.KeysEqual(cert.SignatureKey, caSigner.PublicKey()) {
+				return false
+			}
+
+			return new(gossh.CertChecker).CheckCert(ctx.User(), cert) == nil
+		},
+	}
+	sshServer.AddHostKey(hostCertSigner)
+
+	return testServeSSH(t, sshServer)
+}
+
+// testSSHCertificate signs a user certificate for the public key, valid for
+// the given duration, and returns it in authorized keys format.
+func testSSHCertificate(t *testing.T, caPrivateKey string, publicKey string, validFor time.Duration) string {
+	t.Helper()
+
+	caSigner, err := gossh.ParsePrivateKey([]byte(caPrivateKey))
 	if err != nil {
 		t.Fatal(err)
 	}
 
+	key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(publicKey)) //nolint:dogsled
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := &gossh.Certificate{
+		Key:         key,
+		CertType:    gossh.UserCert,
+		ValidAfter:  uint64(time.Now().Add(-time.Hour).Unix()), //nolint:gosec
+		ValidBefore: uint64(time.Now().Add(validFor).Unix()),   //nolint:gosec
+	}
+
+	if err := cert.SignCert(rand.Reader, caSigner); err != nil {
+		t.Fatal(err)
+	}
+
+	return strings.TrimSpace(string(gossh.MarshalAuthorizedKey(cert)))
+}
+
+func testServeSSH(t *testing.T, sshServer *ssh.Server) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "localhost:0") //nolint:noctx
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sshServer.Handler = func(s ssh.Session) {
+		if _, err := s.Write([]byte("hello world!")); err != nil {
+			t.Error(err)
+		}
+	}
+
 	go sshServer.Serve(listener) //nolint:errcheck
 
 	t.Cleanup(func() {
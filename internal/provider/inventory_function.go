@@ -0,0 +1,346 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+)
+
+var (
+	_ function.Function = (*InventoryFunction)(nil)
+)
+
+func NewInventoryFunction() function.Function { //nolint:ireturn
+	return &InventoryFunction{}
+}
+
+type InventoryFunction struct{}
+
+func (f *InventoryFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "inventory"
+}
+
+func (f *InventoryFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build an Ansible YAML inventory from hosts, groups and variables.",
+		Description:         "Build an Ansible YAML inventory, ready for the 'inventory' attribute. Hosts and their variables are defined under 'all', and groups refer to their hosts and child groups by name. Host and group names are validated, and group cycles are rejected.",
+		MarkdownDescription: "Build an Ansible [YAML inventory](https://docs.ansible.com/ansible/latest/collections/ansible/builtin/yaml_inventory.html), ready for the `inventory` attribute. Hosts and their variables are defined under `all`, and groups refer to their hosts and child groups by name. Host and group names are validated, and group cycles are rejected.",
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:                "hosts",
+				Description:         "Map of host name to either the address of the host, or an object with the optional attributes 'host', 'port' and 'user' (shorthands for 'ansible_host', 'ansible_port' and 'ansible_user'), 'groups' (list of group names) and 'vars' (host variables).",
+				MarkdownDescription: "Map of host name to either the address of the host, or an object with the optional attributes `host`, `port` and `user` (shorthands for `ansible_host`, `ansible_port` and `ansible_user`), `groups` (list of group names) and `vars` (host variables).",
+			},
+			function.DynamicParameter{
+				Name:                "groups",
+				Description:         "Map of group name to an object with the optional attributes 'hosts' (list of host names), 'children' (list of group names) and 'vars' (group variables). Groups named by hosts are created when missing. May be null.",
+				MarkdownDescription: "Map of group name to an object with the optional attributes `hosts` (list of host names), `children` (list of group names) and `vars` (group variables). Groups named by hosts are created when missing. May be null.",
+				AllowNullValue:      true,
+			},
+			function.DynamicParameter{
+				Name:                "vars",
+				Description:         "Variables for all hosts. May be null.",
+				MarkdownDescription: "Variables for all hosts. May be null.",
+				AllowNullValue:      true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *InventoryFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var hostsValue, groupsValue, varsValue types.Dynamic
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &hostsValue, &groupsValue, &varsValue))
+
+	if resp.Error != nil {
+		return
+	}
+
+	hosts, err := inventoryHosts(hostsValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+
+		return
+	}
+
+	groups, err := inventoryGroups(groupsValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+
+		return
+	}
+
+	vars, err := inventoryEntries("vars", varsValue)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+
+		return
+	}
+
+	inventory, err := ansible.BuildInventory(hosts, groups, vars)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, inventory))
+}
+
+func inventoryHosts(value attr.Value) ([]ansible.InventoryHost, error) {
+	entries, err := inventoryEntries("hosts", value)
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]ansible.InventoryHost, 0, len(entries))
+
+	for _, name := range slices.Sorted(maps.Keys(entries)) {
+		host := ansible.InventoryHost{Name: name}
+
+		if address, ok := entries[name].(string); ok {
+			host.Host = address
+			hosts = append(hosts, host)
+
+			continue
+		}
+
+		attributes, err := inventoryObject(fmt.Sprintf("host '%s'", name), entries[name], "host", "port", "user", "groups", "vars")
+		if err != nil {
+			return nil, err
+		}
+
+		host.Host, err = inventoryString(fmt.Sprintf("host '%s' host", name), attributes["host"])
+		if err != nil {
+			return nil, err
+		}
+
+		if port, ok := attributes["port"]; ok && port != nil {
+			number, ok := port.(int64)
+			if !ok {
+				return nil, fmt.Errorf("host '%s' port must be a whole number", name)
+			}
+
+			host.Port = number
+		}
+
+		host.User, err = inventoryString(fmt.Sprintf("host '%s' user", name), attributes["user"])
+		if err != nil {
+			return nil, err
+		}
+
+		host.Groups, err = inventoryStrings(fmt.Sprintf("host '%s' groups", name), attributes["groups"])
+		if err != nil {
+			return nil, err
+		}
+
+		host.Vars, err = inventoryMap(fmt.Sprintf("host '%s' vars", name), attributes["vars"])
+		if err != nil {
+			return nil, err
+		}
+
+		hosts = append(hosts, host)
+	}
+
+	return hosts, nil
+}
+
+func inventoryGroups(value attr.Value) ([]ansible.InventoryGroup, error) {
+	entries, err := inventoryEntries("groups", value)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]ansible.InventoryGroup, 0, len(entries))
+
+	for _, name := range slices.Sorted(maps.Keys(entries)) {
+		group := ansible.InventoryGroup{Name: name}
+
+		attributes, err := inventoryObject(fmt.Sprintf("group '%s'", name), entries[name], "hosts", "children", "vars")
+		if err != nil {
+			return nil, err
+		}
+
+		group.Hosts, err = inventoryStrings(fmt.Sprintf("group '%s' hosts", name), attributes["hosts"])
+		if err != nil {
+			return nil, err
+		}
+
+		group.Children, err = inventoryStrings(fmt.Sprintf("group '%s' children", name), attributes["children"])
+		if err != nil {
+			return nil, err
+		}
+
+		group.Vars, err = inventoryMap(fmt.Sprintf("group '%s' vars", name), attributes["vars"])
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+func inventoryEntries(name string, value attr.Value) (map[string]any, error) {
+	converted, err := goValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return inventoryMap(name, converted)
+}
+
+func inventoryObject(name string, value any, allowed ...string) (map[string]any, error) {
+	attributes, err := inventoryMap(name, value)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, attribute := range slices.Sorted(maps.Keys(attributes)) {
+		if !slices.Contains(allowed, attribute) {
+			return nil, fmt.Errorf("%s has unsupported attribute '%s', supported attributes: %s", name, attribute, strings.Join(allowed, ", "))
+		}
+	}
+
+	return attributes, nil
+}
+
+func inventoryMap(name string, value any) (map[string]any, error) {
+	if value == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	typed, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a map or object", name)
+	}
+
+	return typed, nil
+}
+
+func inventoryString(name string, value any) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	typed, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("%s must be a string", name)
+	}
+
+	return typed, nil
+}
+
+func inventoryStrings(name string, value any) ([]string, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	elements, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a list of strings", name)
+	}
+
+	output := make([]string, 0, len(elements))
+	for _, element := range elements {
+		typed, ok := element.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list of strings", name)
+		}
+
+		output = append(output, typed)
+	}
+
+	return output, nil
+}
+
+// goValue converts a known Terraform value into plain Go values, as YAML would
+// decode them: whole numbers become int64.
+func goValue(value attr.Value) (any, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	if value.IsUnknown() {
+		return nil, fmt.Errorf("value must be known")
+	}
+
+	switch typed := value.(type) {
+	case basetypes.DynamicValue:
+		return goValue(typed.UnderlyingValue())
+	case basetypes.StringValue:
+		return typed.ValueString(), nil
+	case basetypes.BoolValue:
+		return typed.ValueBool(), nil
+	case basetypes.NumberValue:
+		return goNumber(typed.ValueBigFloat()), nil
+	case basetypes.Int64Value:
+		return typed.ValueInt64(), nil
+	case basetypes.Float64Value:
+		return typed.ValueFloat64(), nil
+	case basetypes.ListValue:
+		return goElements(typed.Elements())
+	case basetypes.SetValue:
+		return goElements(typed.Elements())
+	case basetypes.TupleValue:
+		return goElements(typed.Elements())
+	case basetypes.MapValue:
+		return goAttributes(typed.Elements())
+	case basetypes.ObjectValue:
+		return goAttributes(typed.Attributes())
+	}
+
+	return nil, fmt.Errorf("unsupported value type %T", value)
+}
+
+func goNumber(number *big.Float) any {
+	if number.IsInt() {
+		if integer, accuracy := number.Int64(); accuracy == big.Exact {
+			return integer
+		}
+	}
+
+	float, _ := number.Float64()
+
+	return float
+}
+
+func goElements(elements []attr.Value) ([]any, error) {
+	output := make([]any, 0, len(elements))
+	for _, element := range elements {
+		converted, err := goValue(element)
+		if err != nil {
+			return nil, err
+		}
+
+		output = append(output, converted)
+	}
+
+	return output, nil
+}
+
+func goAttributes(attributes map[string]attr.Value) (map[string]any, error) {
+	output := make(map[string]any, len(attributes))
+	for name, attribute := range attributes {
+		converted, err := goValue(attribute)
+		if err != nil {
+			return nil, err
+		}
+
+		output[name] = converted
+	}
+
+	return output, nil
+}
@@ -0,0 +1,89 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+func TestAccInventoryFunction_basic(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::ansible::inventory(
+						{
+							web1 = "10.0.0.1"
+							db1 = {
+								host   = "10.0.0.2"
+								port   = 2222
+								groups = ["db"]
+							}
+						},
+						{
+							web  = { hosts = ["web1"], vars = { http_port = 80 } }
+							prod = { children = ["web", "db"] }
+						},
+						{ env = "prod" },
+					)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact(`all:
+    children:
+        db:
+            hosts:
+                db1: {}
+        prod:
+            children:
+                db: {}
+                web: {}
+        web:
+            hosts:
+                web1: {}
+            vars:
+                http_port: 80
+    hosts:
+        db1:
+            ansible_host: 10.0.0.2
+            ansible_port: 2222
+        web1:
+            ansible_host: 10.0.0.1
+    vars:
+        env: prod
+`)),
+				},
+			},
+		},
+	})
+}
+
+func TestAccInventoryFunction_cycle(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::ansible::inventory(
+						{ web1 = "10.0.0.1" },
+						{
+							a = { children = ["b"] }
+							b = { children = ["a"] }
+						},
+						null,
+					)
+				}`,
+				ExpectError: regexp.MustCompile(`group cycle a -> b -> a`),
+			},
+		},
+	})
+}
@@ -30,6 +30,7 @@ func (m NavigatorRunActionModel) Value(ctx context.Context, opts *providerOption
 	*runData = navigatorRunData{
 		hostDir:    navigatorRunDirPath(opts.BaseRunDirectory, uuid.New().String(), 0),
 		persistDir: opts.PersistRunDirectory,
+		limiter:    opts.RunLimiter,
 	}
 
 	diags.Append(runData.Load(ctx, m.NavigatorRunCommonModel)...)
@@ -75,7 +76,7 @@ func (a *NavigatorRunAction) Invoke(ctx context.Context, req action.InvokeReques
 	var data *NavigatorRunActionModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
-	resp.Diagnostics.Append(data.SetDefaults(ctx)...)
+	resp.Diagnostics.Append(data.SetDefaults(ctx, a.opts.Defaults)...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -2,6 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"io/fs"
+	"maps"
+	"math/big"
+	"strconv"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -15,16 +21,29 @@ import (
 type NavigatorRunCommonModel struct {
 	Playbook               types.String `tfsdk:"playbook"`
 	Inventory              types.String `tfsdk:"inventory"`
+	Inventories            types.Map    `tfsdk:"inventories"`
 	WorkingDirectory       types.String `tfsdk:"working_directory"`
 	ExecutionEnvironment   types.Object `tfsdk:"execution_environment"`
 	AnsibleNavigatorBinary types.String `tfsdk:"ansible_navigator_binary"`
+	AnsibleRunner          types.Object `tfsdk:"ansible_runner"`
 	AnsibleOptions         types.Object `tfsdk:"ansible_options"`
+	Requirements           types.Object `tfsdk:"requirements"`
+	Retry                  types.Object `tfsdk:"retry"`
+	Files                  types.Map    `tfsdk:"files"`
 	Timezone               types.String `tfsdk:"timezone"`
+	CancelGracePeriod      types.String `tfsdk:"cancel_grace_period"`
+	RedactValues           types.List   `tfsdk:"redact_values"`
+	JUnitReportPath        types.String `tfsdk:"junit_report_path"`
+	TimingSlowestTasks     types.Int64  `tfsdk:"timing_slowest_tasks"`
 }
 
-func (m *NavigatorRunCommonModel) SetDefaults(ctx context.Context) diag.Diagnostics {
+// SetDefaults fills in what the resource schema defaults would, for surfaces
+// without schema defaults, then the provider defaults.
+func (m *NavigatorRunCommonModel) SetDefaults(ctx context.Context, providerDefaults NavigatorRunDefaultsModel) diag.Diagnostics {
 	var diags diag.Diagnostics
 
+	config := *m
+
 	if m.WorkingDirectory.IsNull() {
 		m.WorkingDirectory = types.StringValue(defaultNavigatorRunWorkingDir)
 	}
@@ -52,6 +71,10 @@ func (m *NavigatorRunCommonModel) SetDefaults(ctx context.Context) diag.Diagnost
 		eeModel.PullPolicy = types.StringValue(defaultNavigatorRunPullPolicy)
 	}
 
+	if eeModel.RequireImageDigest.IsNull() {
+		eeModel.RequireImageDigest = types.BoolValue(defaultNavigatorRunRequireImageDigest)
+	}
+
 	eeValue, newDiags := types.ObjectValueFrom(ctx, ExecutionEnvironmentModel{}.AttrTypes(), eeModel)
 	diags.Append(newDiags...)
 	m.ExecutionEnvironment = eeValue
@@ -75,9 +98,88 @@ func (m *NavigatorRunCommonModel) SetDefaults(ctx context.Context) diag.Diagnost
 		m.Timezone = types.StringValue(defaultNavigatorRunTimezone)
 	}
 
+	if m.CancelGracePeriod.IsNull() {
+		m.CancelGracePeriod = types.StringValue(defaultNavigatorRunCancelGracePeriod.String())
+	}
+
+	diags.Append(m.ApplyProviderDefaults(ctx, config, providerDefaults)...)
+
 	return diags
 }
 
+// ApplyProviderDefaults replaces each value not set in config with the
+// provider default, field by field within nested attributes.
+func (m *NavigatorRunCommonModel) ApplyProviderDefaults(ctx context.Context, config NavigatorRunCommonModel, providerDefaults NavigatorRunDefaultsModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if config.Timezone.IsNull() && !providerDefaults.Timezone.IsNull() {
+		m.Timezone = providerDefaults.Timezone
+	}
+
+	if config.CancelGracePeriod.IsNull() && !providerDefaults.CancelGracePeriod.IsNull() {
+		m.CancelGracePeriod = providerDefaults.CancelGracePeriod
+	}
+
+	eeValue, newDiags := mergeProviderDefaults(ctx, m.ExecutionEnvironment, config.ExecutionEnvironment, providerDefaults.ExecutionEnvironment, ExecutionEnvironmentModel{}.Defaults().Attributes())
+	diags.Append(newDiags...)
+	m.ExecutionEnvironment = eeValue
+
+	optsFallback := maps.Clone(AnsibleOptionsModel{}.Defaults().Attributes())
+	// recorded per resource, never a provider default
+	delete(optsFallback, "known_hosts")
+
+	optsValue, newDiags := mergeProviderDefaults(ctx, m.AnsibleOptions, config.AnsibleOptions, providerDefaults.AnsibleOptions, optsFallback)
+	diags.Append(newDiags...)
+	m.AnsibleOptions = optsValue
+
+	return diags
+}
+
+// mergeProviderDefaults sets each attribute in fallback that config leaves
+// null to the provider default, or to the fallback without one. These
+// attributes are computed, so Terraform would otherwise plan them as unknown,
+// or keep a provider default from state that has since been removed.
+func mergeProviderDefaults(ctx context.Context, value types.Object, config types.Object, providerDefaults types.Object, fallback map[string]attr.Value) (types.Object, diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return value, nil
+	}
+
+	attributes := maps.Clone(value.Attributes())
+
+	configAttributes := map[string]attr.Value{}
+	if !config.IsNull() && !config.IsUnknown() {
+		configAttributes = config.Attributes()
+	}
+
+	defaultAttributes := map[string]attr.Value{}
+	if !providerDefaults.IsNull() && !providerDefaults.IsUnknown() {
+		defaultAttributes = providerDefaults.Attributes()
+	}
+
+	for name, fallbackValue := range fallback {
+		if configValue, ok := configAttributes[name]; ok && !configValue.IsNull() {
+			continue
+		}
+
+		if defaultValue, ok := defaultAttributes[name]; ok && !defaultValue.IsNull() {
+			attributes[name] = defaultValue
+
+			continue
+		}
+
+		attributes[name] = fallbackValue
+	}
+
+	return types.ObjectValue(value.AttributeTypes(ctx), attributes)
+}
+
+type NavigatorRunDefaultsModel struct {
+	ExecutionEnvironment types.Object `tfsdk:"execution_environment"`
+	AnsibleOptions       types.Object `tfsdk:"ansible_options"`
+	Timezone             types.String `tfsdk:"timezone"`
+	CancelGracePeriod    types.String `tfsdk:"cancel_grace_period"`
+}
+
 type ExecutionEnvironmentModel struct {
 	ContainerEngine          types.String `tfsdk:"container_engine"`
 	Enabled                  types.Bool   `tfsdk:"enabled"`
@@ -87,6 +189,7 @@ type ExecutionEnvironmentModel struct {
 	PullArguments            types.List   `tfsdk:"pull_arguments"`
 	PullPolicy               types.String `tfsdk:"pull_policy"`
 	ContainerOptions         types.List   `tfsdk:"container_options"`
+	RequireImageDigest       types.Bool   `tfsdk:"require_image_digest"`
 }
 
 type AnsibleOptionsModel struct {
@@ -97,13 +200,54 @@ type AnsibleOptionsModel struct {
 	Limit           types.List   `tfsdk:"limit"`
 	Tags            types.List   `tfsdk:"tags"`
 	PrivateKeys     types.List   `tfsdk:"private_keys"`
+	SSHAgent        types.Bool   `tfsdk:"ssh_agent"`
+	VaultPasswords  types.Map    `tfsdk:"vault_passwords"`
 	KnownHosts      types.List   `tfsdk:"known_hosts"`
 	HostKeyChecking types.Bool   `tfsdk:"host_key_checking"`
+	SSHProxy        types.Object `tfsdk:"ssh_proxy"`
+}
+
+type AnsibleRunnerModel struct {
+	Binary types.String `tfsdk:"binary"`
+}
+
+type RequirementsModel struct {
+	Contents types.String `tfsdk:"contents"`
+	Source   types.String `tfsdk:"source"`
+}
+
+type RetryModel struct {
+	MaxAttempts          types.Int64  `tfsdk:"max_attempts"`
+	InitialBackoff       types.String `tfsdk:"initial_backoff"`
+	MaxBackoff           types.String `tfsdk:"max_backoff"`
+	RetryOn              types.List   `tfsdk:"retry_on"`
+	UnreachableHostsOnly types.Bool   `tfsdk:"unreachable_hosts_only"`
+}
+
+type ProjectFileModel struct {
+	Contents types.String `tfsdk:"contents"`
+	Mode     types.String `tfsdk:"mode"`
+}
+
+type ArtifactExportModel struct {
+	Directory types.String `tfsdk:"directory"`
+	Gzip      types.Bool   `tfsdk:"gzip"`
+	Retain    types.Int64  `tfsdk:"retain"`
 }
 
 type PrivateKeyModel struct {
-	Name types.String `tfsdk:"name"`
-	Data types.String `tfsdk:"data"`
+	Name        types.String `tfsdk:"name"`
+	Data        types.String `tfsdk:"data"`
+	Passphrase  types.String `tfsdk:"passphrase"`
+	Certificate types.String `tfsdk:"certificate"`
+}
+
+type SSHProxyModel struct {
+	Host           types.String `tfsdk:"host"`
+	Port           types.Int64  `tfsdk:"port"`
+	User           types.String `tfsdk:"user"`
+	PrivateKeyName types.String `tfsdk:"private_key_name"`
+	KnownHosts     types.List   `tfsdk:"known_hosts"`
 }
 
 type ArtifactQueryModel struct {
@@ -111,6 +255,36 @@ type ArtifactQueryModel struct {
 	Results  types.List   `tfsdk:"results"`
 }
 
+type HostStatsModel struct {
+	OK          types.Int64 `tfsdk:"ok"`
+	Changed     types.Int64 `tfsdk:"changed"`
+	Unreachable types.Int64 `tfsdk:"unreachable"`
+	Failed      types.Int64 `tfsdk:"failed"`
+	Skipped     types.Int64 `tfsdk:"skipped"`
+	Rescued     types.Int64 `tfsdk:"rescued"`
+	Ignored     types.Int64 `tfsdk:"ignored"`
+}
+
+type TimingModel struct {
+	WallTimeSeconds types.Float64 `tfsdk:"wall_time_seconds"`
+	SlowestTasks    types.List    `tfsdk:"slowest_tasks"`
+}
+
+type TaskTimingModel struct {
+	Play            types.String  `tfsdk:"play"`
+	Task            types.String  `tfsdk:"task"`
+	DurationSeconds types.Float64 `tfsdk:"duration_seconds"`
+}
+
+type TaskResultModel struct {
+	Play    types.String `tfsdk:"play"`
+	Task    types.String `tfsdk:"task"`
+	Action  types.String `tfsdk:"action"`
+	Host    types.String `tfsdk:"host"`
+	Status  types.String `tfsdk:"status"`
+	Changed types.Bool   `tfsdk:"changed"`
+}
+
 func (ExecutionEnvironmentModel) AttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
 		"container_engine":           types.StringType,
@@ -121,6 +295,7 @@ func (ExecutionEnvironmentModel) AttrTypes() map[string]attr.Type {
 		"pull_arguments":             types.ListType{ElemType: types.StringType},
 		"pull_policy":                types.StringType,
 		"container_options":          types.ListType{ElemType: types.StringType},
+		"require_image_digest":       types.BoolType,
 	}
 }
 
@@ -136,6 +311,7 @@ func (ExecutionEnvironmentModel) Defaults() types.Object {
 			"pull_arguments":             types.ListNull(types.StringType),
 			"pull_policy":                types.StringValue(defaultNavigatorRunPullPolicy),
 			"container_options":          types.ListNull(types.StringType),
+			"require_image_digest":       types.BoolValue(defaultNavigatorRunRequireImageDigest),
 		},
 	)
 }
@@ -177,6 +353,8 @@ func (m ExecutionEnvironmentModel) Value(ctx context.Context, execEnv *navigator
 	}
 	execEnv.ContainerOptions = containerOptions
 
+	execEnv.RequireDigest = m.RequireImageDigest.ValueBool()
+
 	return diags
 }
 
@@ -189,8 +367,11 @@ func (AnsibleOptionsModel) AttrTypes() map[string]attr.Type {
 		"limit":             types.ListType{ElemType: types.StringType},
 		"tags":              types.ListType{ElemType: types.StringType},
 		"private_keys":      types.ListType{ElemType: types.ObjectType{AttrTypes: PrivateKeyModel{}.AttrTypes()}},
+		"ssh_agent":         types.BoolType,
+		"vault_passwords":   types.MapType{ElemType: types.StringType},
 		"known_hosts":       types.ListType{ElemType: types.StringType},
 		"host_key_checking": types.BoolType,
+		"ssh_proxy":         types.ObjectType{AttrTypes: SSHProxyModel{}.AttrTypes()},
 	}
 }
 
@@ -205,8 +386,11 @@ func (AnsibleOptionsModel) Defaults() types.Object {
 			"limit":             types.ListNull(types.StringType),
 			"tags":              types.ListNull(types.StringType),
 			"private_keys":      types.ListNull(types.ObjectType{AttrTypes: PrivateKeyModel{}.AttrTypes()}),
+			"ssh_agent":         types.BoolNull(),
+			"vault_passwords":   types.MapNull(types.StringType),
 			"known_hosts":       types.ListUnknown(types.StringType),
 			"host_key_checking": types.BoolNull(),
+			"ssh_proxy":         types.ObjectNull(SSHProxyModel{}.AttrTypes()),
 		},
 	)
 }
@@ -251,10 +435,123 @@ func (m *AnsibleOptionsModel) Set(ctx context.Context, run navigatorRunData) dia
 	return diags
 }
 
+func (AnsibleRunnerModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"binary": types.StringType,
+	}
+}
+
+func (RequirementsModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"contents": types.StringType,
+		"source":   types.StringType,
+	}
+}
+
+func (m RequirementsModel) Value(_ context.Context, requirements *ansible.Requirements) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	requirements.Contents = m.Contents.ValueString()
+	requirements.Source = m.Source.ValueString()
+
+	return diags
+}
+
+func (RetryModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"max_attempts":           types.Int64Type,
+		"initial_backoff":        types.StringType,
+		"max_backoff":            types.StringType,
+		"retry_on":               types.ListType{ElemType: types.StringType},
+		"unreachable_hosts_only": types.BoolType,
+	}
+}
+
+func (m RetryModel) Value(ctx context.Context, policy *navigator.RetryPolicy) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	policy.MaxAttempts = defaultNavigatorRunRetryMaxAttempts
+	if !m.MaxAttempts.IsNull() {
+		policy.MaxAttempts = int(m.MaxAttempts.ValueInt64())
+	}
+
+	// validated by stringIsBackoff
+	policy.InitialBackoff = defaultNavigatorRunRetryInitialBackoff
+	if !m.InitialBackoff.IsNull() {
+		policy.InitialBackoff, _ = time.ParseDuration(m.InitialBackoff.ValueString())
+	}
+
+	policy.MaxBackoff = defaultNavigatorRunRetryMaxBackoff
+	if !m.MaxBackoff.IsNull() {
+		policy.MaxBackoff, _ = time.ParseDuration(m.MaxBackoff.ValueString())
+	}
+
+	policy.RetryOn = navigator.RetryOns{defaultNavigatorRunRetryOn}
+	if !m.RetryOn.IsNull() {
+		var retryOn []string
+		diags.Append(m.RetryOn.ElementsAs(ctx, &retryOn, false)...)
+
+		policy.RetryOn = make(navigator.RetryOns, 0, len(retryOn))
+		for _, outcome := range retryOn {
+			policy.RetryOn = append(policy.RetryOn, navigator.RetryOn(outcome))
+		}
+	}
+
+	policy.UnreachableHostsOnly = m.UnreachableHostsOnly.ValueBool()
+
+	return diags
+}
+
+func (ProjectFileModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"contents": types.StringType,
+		"mode":     types.StringType,
+	}
+}
+
+func (m ProjectFileModel) Value(_ context.Context, name string, file *ansible.ProjectFile) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	file.Path = name
+	file.Contents = m.Contents.ValueString()
+
+	// validated by stringIsFileMode
+	if !m.Mode.IsNull() {
+		mode, _ := strconv.ParseUint(m.Mode.ValueString(), 8, 32)
+		file.Mode = fs.FileMode(mode)
+	}
+
+	return diags
+}
+
+func (ArtifactExportModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"directory": types.StringType,
+		"gzip":      types.BoolType,
+		"retain":    types.Int64Type,
+	}
+}
+
+func (m ArtifactExportModel) Value(_ context.Context, export *navigator.ArtifactExport) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	export.Dir = m.Directory.ValueString()
+	export.Gzip = m.Gzip.ValueBool()
+
+	export.Retain = defaultNavigatorRunArtifactExportRetain
+	if !m.Retain.IsNull() {
+		export.Retain = int(m.Retain.ValueInt64())
+	}
+
+	return diags
+}
+
 func (PrivateKeyModel) AttrTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"name": types.StringType,
-		"data": types.StringType,
+		"name":        types.StringType,
+		"data":        types.StringType,
+		"passphrase":  types.StringType,
+		"certificate": types.StringType,
 	}
 }
 
@@ -263,6 +560,33 @@ func (m PrivateKeyModel) Value(_ context.Context, key *ansible.PrivateKey) diag.
 
 	key.Name = m.Name.ValueString()
 	key.Data = m.Data.ValueString()
+	key.Passphrase = m.Passphrase.ValueString()
+	key.Certificate = m.Certificate.ValueString()
+
+	return diags
+}
+
+func (SSHProxyModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"host":             types.StringType,
+		"port":             types.Int64Type,
+		"user":             types.StringType,
+		"private_key_name": types.StringType,
+		"known_hosts":      types.ListType{ElemType: types.StringType},
+	}
+}
+
+func (m SSHProxyModel) Value(ctx context.Context, proxy *ansible.SSHProxy) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	proxy.Host = m.Host.ValueString()
+	proxy.Port = m.Port.ValueInt64()
+	proxy.User = m.User.ValueString()
+	proxy.PrivateKeyName = m.PrivateKeyName.ValueString()
+
+	if !m.KnownHosts.IsNull() {
+		diags.Append(m.KnownHosts.ElementsAs(ctx, &proxy.KnownHosts, false)...)
+	}
 
 	return diags
 }
@@ -294,3 +618,171 @@ func (m *ArtifactQueryModel) Set(ctx context.Context, query ansible.PlaybookArti
 
 	return diags
 }
+
+// artifactQueryResult is the decoded result of a query, a single value when the
+// filter yields one result, a tuple otherwise.
+func artifactQueryResult(values []any) (attr.Value, error) { //nolint:ireturn
+	if len(values) == 1 {
+		return jqValue(values[0])
+	}
+
+	return jqTupleValue(values)
+}
+
+// jqValue converts a value produced by jq, which is always JSON compatible.
+func jqValue(value any) (attr.Value, error) { //nolint:ireturn
+	switch value := value.(type) {
+	case nil:
+		return types.DynamicNull(), nil
+	case bool:
+		return types.BoolValue(value), nil
+	case int:
+		return types.NumberValue(new(big.Float).SetInt64(int64(value))), nil
+	case float64:
+		return types.NumberValue(big.NewFloat(value)), nil
+	case *big.Int:
+		return types.NumberValue(new(big.Float).SetInt(value)), nil
+	case string:
+		return types.StringValue(value), nil
+	case []any:
+		return jqTupleValue(value)
+	case map[string]any:
+		attrTypes := make(map[string]attr.Type, len(value))
+		attrValues := make(map[string]attr.Value, len(value))
+
+		for name, element := range value {
+			elementValue, err := jqValue(element)
+			if err != nil {
+				return nil, err
+			}
+
+			attrTypes[name] = elementValue.Type(context.Background())
+			attrValues[name] = elementValue
+		}
+
+		objectValue, diags := types.ObjectValue(attrTypes, attrValues)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to convert JQ object, %s", diags.Errors()[0].Detail())
+		}
+
+		return objectValue, nil
+	}
+
+	return nil, fmt.Errorf("unsupported JQ result type %T", value)
+}
+
+func jqTupleValue(values []any) (attr.Value, error) { //nolint:ireturn
+	elemTypes := make([]attr.Type, 0, len(values))
+	elemValues := make([]attr.Value, 0, len(values))
+
+	for _, element := range values {
+		elementValue, err := jqValue(element)
+		if err != nil {
+			return nil, err
+		}
+
+		elemTypes = append(elemTypes, elementValue.Type(context.Background()))
+		elemValues = append(elemValues, elementValue)
+	}
+
+	tupleValue, diags := types.TupleValue(elemTypes, elemValues)
+	if diags.HasError() {
+		return nil, fmt.Errorf("failed to convert JQ array, %s", diags.Errors()[0].Detail())
+	}
+
+	return tupleValue, nil
+}
+
+func (HostStatsModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"ok":          types.Int64Type,
+		"changed":     types.Int64Type,
+		"unreachable": types.Int64Type,
+		"failed":      types.Int64Type,
+		"skipped":     types.Int64Type,
+		"rescued":     types.Int64Type,
+		"ignored":     types.Int64Type,
+	}
+}
+
+func (m *HostStatsModel) Set(_ context.Context, stats ansible.HostStats) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.OK = types.Int64Value(stats.OK)
+	m.Changed = types.Int64Value(stats.Changed)
+	m.Unreachable = types.Int64Value(stats.Unreachable)
+	m.Failed = types.Int64Value(stats.Failed)
+	m.Skipped = types.Int64Value(stats.Skipped)
+	m.Rescued = types.Int64Value(stats.Rescued)
+	m.Ignored = types.Int64Value(stats.Ignored)
+
+	return diags
+}
+
+func (TaskResultModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"play":    types.StringType,
+		"task":    types.StringType,
+		"action":  types.StringType,
+		"host":    types.StringType,
+		"status":  types.StringType,
+		"changed": types.BoolType,
+	}
+}
+
+func (m *TaskResultModel) Set(_ context.Context, result ansible.TaskResult) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.Play = types.StringValue(result.Play)
+	m.Task = types.StringValue(result.Name)
+	m.Action = types.StringValue(result.Action)
+	m.Host = types.StringValue(result.Host)
+	m.Status = types.StringValue(result.Status.String())
+	m.Changed = types.BoolValue(result.Changed)
+
+	return diags
+}
+
+func (TimingModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"wall_time_seconds": types.Float64Type,
+		"slowest_tasks":     types.ListType{ElemType: types.ObjectType{AttrTypes: TaskTimingModel{}.AttrTypes()}},
+	}
+}
+
+func (m *TimingModel) Set(ctx context.Context, timeline ansible.Timeline, slowestTasks int) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.WallTimeSeconds = types.Float64Value(durationSeconds(timeline.WallTime()))
+
+	tasks := timeline.SlowestTasks(slowestTasks)
+
+	tasksModel := make([]TaskTimingModel, 0, len(tasks))
+	for _, task := range tasks {
+		tasksModel = append(tasksModel, TaskTimingModel{
+			Play:            types.StringValue(task.Play),
+			Task:            types.StringValue(task.Name),
+			DurationSeconds: types.Float64Value(durationSeconds(task.Duration())),
+		})
+	}
+
+	tasksValue, newDiags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: TaskTimingModel{}.AttrTypes()}, tasksModel)
+	diags.Append(newDiags...)
+	m.SlowestTasks = tasksValue
+
+	return diags
+}
+
+func (TaskTimingModel) AttrTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"play":             types.StringType,
+		"task":             types.StringType,
+		"duration_seconds": types.Float64Type,
+	}
+}
+
+// durationSeconds rounds to the millisecond, finer than job event timestamps
+// are worth.
+func durationSeconds(duration time.Duration) float64 {
+	return duration.Round(time.Millisecond).Seconds()
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestArtifactQueryResult(t *testing.T) {
+	t.Parallel()
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := map[string]struct {
+		values []any
+		want   attr.Value
+	}{
+		"string": {
+			values: []any{"ok"},
+			want:   types.StringValue("ok"),
+		},
+		"null": {
+			values: []any{nil},
+			want:   types.DynamicNull(),
+		},
+		"null_in_object": {
+			values: []any{map[string]any{"msg": nil, "ok": true}},
+			want: types.ObjectValueMust(
+				map[string]attr.Type{"msg": types.DynamicType, "ok": types.BoolType},
+				map[string]attr.Value{"msg": types.DynamicNull(), "ok": types.BoolValue(true)},
+			),
+		},
+		"int": {
+			values: []any{2},
+			want:   types.NumberValue(big.NewFloat(2)),
+		},
+		"float64": {
+			values: []any{2.5},
+			want:   types.NumberValue(big.NewFloat(2.5)),
+		},
+		"big_int": {
+			values: []any{huge},
+			want:   types.NumberValue(new(big.Float).SetInt(huge)),
+		},
+		"nested": {
+			values: []any{map[string]any{"hosts": []any{"a", map[string]any{"port": 22}}}},
+			want: types.ObjectValueMust(
+				map[string]attr.Type{
+					"hosts": types.TupleType{ElemTypes: []attr.Type{
+						types.StringType,
+						types.ObjectType{AttrTypes: map[string]attr.Type{"port": types.NumberType}},
+					}},
+				},
+				map[string]attr.Value{
+					"hosts": types.TupleValueMust(
+						[]attr.Type{types.StringType, types.ObjectType{AttrTypes: map[string]attr.Type{"port": types.NumberType}}},
+						[]attr.Value{
+							types.StringValue("a"),
+							types.ObjectValueMust(map[string]attr.Type{"port": types.NumberType}, map[string]attr.Value{"port": types.NumberValue(big.NewFloat(22))}),
+						},
+					),
+				},
+			),
+		},
+		"multiple": {
+			values: []any{"a", 1, false},
+			want: types.TupleValueMust(
+				[]attr.Type{types.StringType, types.NumberType, types.BoolType},
+				[]attr.Value{types.StringValue("a"), types.NumberValue(big.NewFloat(1)), types.BoolValue(false)},
+			),
+		},
+		"none": {
+			values: nil,
+			want:   types.TupleValueMust([]attr.Type{}, []attr.Value{}),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := artifactQueryResult(test.values)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !got.Equal(test.want) {
+				t.Errorf("expected %s, got %s", test.want, got)
+			}
+		})
+	}
+}
+
+func TestArtifactQueryResultUnsupported(t *testing.T) {
+	t.Parallel()
+
+	if _, err := artifactQueryResult([]any{[]any{struct{}{}}}); err == nil {
+		t.Error("expected error for unsupported type")
+	}
+}
+
+func TestApplyProviderDefaults(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	withAttributes := func(object types.Object, attributes map[string]attr.Value) types.Object {
+		merged := maps.Clone(object.Attributes())
+		maps.Copy(merged, attributes)
+
+		return types.ObjectValueMust(object.AttributeTypes(ctx), merged)
+	}
+
+	eeDefaults := ExecutionEnvironmentModel{}.Defaults()
+	nullEE := types.ObjectNull(ExecutionEnvironmentModel{}.AttrTypes())
+
+	providerDefaults := NavigatorRunDefaultsModel{
+		ExecutionEnvironment: withAttributes(nullEE, map[string]attr.Value{
+			"container_engine":           types.StringNull(),
+			"enabled":                    types.BoolNull(),
+			"environment_variables_pass": types.ListNull(types.StringType),
+			"environment_variables_set":  types.MapValueMust(types.StringType, map[string]attr.Value{"FROM_DEFAULTS": types.StringValue("true")}),
+			"image":                      types.StringNull(),
+			"pull_arguments":             types.ListNull(types.StringType),
+			"pull_policy":                types.StringValue("missing"),
+			"container_options":          types.ListNull(types.StringType),
+			"require_image_digest":       types.BoolNull(),
+		}),
+		AnsibleOptions: types.ObjectNull(AnsibleOptionsModel{}.AttrTypes()),
+		Timezone:       types.StringValue("America/New_York"),
+	}
+
+	// the resource sets part of execution_environment, and Terraform plans the
+	// computed attributes it leaves null as unknown
+	config := NavigatorRunCommonModel{
+		ExecutionEnvironment: withAttributes(eeDefaults, map[string]attr.Value{
+			"container_engine":     types.StringNull(),
+			"enabled":              types.BoolNull(),
+			"image":                types.StringNull(),
+			"pull_policy":          types.StringValue("tag"),
+			"require_image_digest": types.BoolNull(),
+		}),
+		AnsibleOptions: types.ObjectNull(AnsibleOptionsModel{}.AttrTypes()),
+		Timezone:       types.StringNull(),
+	}
+
+	model := NavigatorRunCommonModel{
+		ExecutionEnvironment: withAttributes(eeDefaults, map[string]attr.Value{
+			"environment_variables_set": types.MapUnknown(types.StringType),
+			"container_options":         types.ListUnknown(types.StringType),
+			"pull_policy":               types.StringValue("tag"),
+		}),
+		AnsibleOptions: withAttributes(AnsibleOptionsModel{}.Defaults(), map[string]attr.Value{
+			"tags":        types.ListValueMust(types.StringType, []attr.Value{types.StringValue("removed-default")}),
+			"known_hosts": types.ListUnknown(types.StringType),
+		}),
+		Timezone: types.StringValue(defaultNavigatorRunTimezone),
+	}
+
+	if diags := model.ApplyProviderDefaults(ctx, config, providerDefaults); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	wantEE := withAttributes(eeDefaults, map[string]attr.Value{
+		"environment_variables_set": providerDefaults.ExecutionEnvironment.Attributes()["environment_variables_set"],
+		"pull_policy":               types.StringValue("tag"),
+	})
+	if !model.ExecutionEnvironment.Equal(wantEE) {
+		t.Errorf("expected execution_environment %s, got %s", wantEE, model.ExecutionEnvironment)
+	}
+
+	// a default no longer set is removed, known_hosts is left to the run
+	wantOpts := withAttributes(AnsibleOptionsModel{}.Defaults(), map[string]attr.Value{
+		"known_hosts": types.ListUnknown(types.StringType),
+	})
+	if !model.AnsibleOptions.Equal(wantOpts) {
+		t.Errorf("expected ansible_options %s, got %s", wantOpts, model.AnsibleOptions)
+	}
+
+	if want := types.StringValue("America/New_York"); !model.Timezone.Equal(want) {
+		t.Errorf("expected timezone %s, got %s", want, model.Timezone)
+	}
+}
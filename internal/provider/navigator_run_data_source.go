@@ -22,10 +22,15 @@ var (
 type NavigatorRunDataSourceModel struct {
 	NavigatorRunCommonModel
 
-	ArtifactQueries types.Map      `tfsdk:"artifact_queries"`
-	ID              types.String   `tfsdk:"id"`
-	Command         types.String   `tfsdk:"command"`
-	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+	ArtifactQueries      types.Map      `tfsdk:"artifact_queries"`
+	ArtifactQueryResults types.Dynamic  `tfsdk:"artifact_query_results"`
+	HostStats            types.Map      `tfsdk:"host_stats"`
+	TaskResults          types.List     `tfsdk:"task_results"`
+	Timing               types.Object   `tfsdk:"timing"`
+	ImageDigest          types.String   `tfsdk:"image_digest"`
+	ID                   types.String   `tfsdk:"id"`
+	Command              types.String   `tfsdk:"command"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (m NavigatorRunDataSourceModel) Value(ctx context.Context, opts *providerOptions, runData *navigatorRunData) diag.Diagnostics {
@@ -34,6 +39,7 @@ func (m NavigatorRunDataSourceModel) Value(ctx context.Context, opts *providerOp
 	*runData = navigatorRunData{
 		hostDir:    navigatorRunDirPath(opts.BaseRunDirectory, m.ID.ValueString(), 0),
 		persistDir: opts.PersistRunDirectory,
+		limiter:    opts.RunLimiter,
 	}
 
 	diags.Append(runData.Load(ctx, m.NavigatorRunCommonModel)...)
@@ -54,7 +60,7 @@ func (m NavigatorRunDataSourceModel) Value(ctx context.Context, opts *providerOp
 }
 
 func (m *NavigatorRunDataSourceModel) Set(ctx context.Context, run navigatorRunData) diag.Diagnostics {
-	return run.Store(ctx, &m.Command, &m.AnsibleOptions, &m.ArtifactQueries)
+	return run.Store(ctx, &m.Command, &m.AnsibleOptions, &m.ArtifactQueries, &m.ArtifactQueryResults, &m.HostStats, &m.TaskResults, &m.Timing, &m.ImageDigest)
 }
 
 type NavigatorRunDataSource struct {
@@ -95,7 +101,7 @@ func (d *NavigatorRunDataSource) Read(ctx context.Context, req datasource.ReadRe
 	var data *NavigatorRunDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
-	resp.Diagnostics.Append(data.SetDefaults(ctx)...)
+	resp.Diagnostics.Append(data.SetDefaults(ctx, d.opts.Defaults)...)
 
 	if resp.Diagnostics.HasError() {
 		return
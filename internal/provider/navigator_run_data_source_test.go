@@ -88,6 +88,11 @@ func TestAccNavigatorRunDataSource_basic(t *testing.T) {
 					statecheck.ExpectKnownValue(navigatorRunDataSource, tfjsonpath.New("ansible_options"), knownvalue.NotNull()),
 					statecheck.ExpectKnownValue(navigatorRunDataSource, tfjsonpath.New("timezone"), knownvalue.NotNull()),
 					statecheck.ExpectKnownValue(navigatorRunDataSource, tfjsonpath.New("artifact_queries"), knownvalue.Null()),
+					statecheck.ExpectKnownValue(navigatorRunDataSource, tfjsonpath.New("host_stats").AtMapKey("local_container").AtMapKey("ok"), knownvalue.Int64Exact(2)),
+					statecheck.ExpectKnownValue(navigatorRunDataSource, tfjsonpath.New("host_stats").AtMapKey("local_container").AtMapKey("failed"), knownvalue.Int64Exact(0)),
+					statecheck.ExpectKnownValue(navigatorRunDataSource, tfjsonpath.New("task_results"), knownvalue.ListSizeExact(2)),
+					statecheck.ExpectKnownValue(navigatorRunDataSource, tfjsonpath.New("task_results").AtSliceIndex(1).AtMapKey("action"), knownvalue.StringExact("ansible.builtin.assert")),
+					statecheck.ExpectKnownValue(navigatorRunDataSource, tfjsonpath.New("task_results").AtSliceIndex(1).AtMapKey("status"), knownvalue.StringExact("ok")),
 					statecheck.ExpectKnownValue(navigatorRunDataSource, tfjsonpath.New("id"), knownvalue.NotNull()),
 					statecheck.ExpectKnownValue(navigatorRunDataSource, tfjsonpath.New("command"), knownvalue.NotNull()),
 					statecheck.ExpectKnownValue(navigatorRunDataSource, tfjsonpath.New("timeouts"), knownvalue.Null()),
@@ -22,10 +22,15 @@ var (
 type NavigatorRunEphemeralResourceModel struct {
 	NavigatorRunCommonModel
 
-	ArtifactQueries types.Map      `tfsdk:"artifact_queries"`
-	ID              types.String   `tfsdk:"id"`
-	Command         types.String   `tfsdk:"command"`
-	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+	ArtifactQueries      types.Map      `tfsdk:"artifact_queries"`
+	ArtifactQueryResults types.Dynamic  `tfsdk:"artifact_query_results"`
+	HostStats            types.Map      `tfsdk:"host_stats"`
+	TaskResults          types.List     `tfsdk:"task_results"`
+	Timing               types.Object   `tfsdk:"timing"`
+	ImageDigest          types.String   `tfsdk:"image_digest"`
+	ID                   types.String   `tfsdk:"id"`
+	Command              types.String   `tfsdk:"command"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (m NavigatorRunEphemeralResourceModel) Value(ctx context.Context, opts *providerOptions, runData *navigatorRunData) diag.Diagnostics {
@@ -34,6 +39,7 @@ func (m NavigatorRunEphemeralResourceModel) Value(ctx context.Context, opts *pro
 	*runData = navigatorRunData{
 		hostDir:    navigatorRunDirPath(opts.BaseRunDirectory, m.ID.ValueString(), 0),
 		persistDir: opts.PersistRunDirectory,
+		limiter:    opts.RunLimiter,
 	}
 
 	diags.Append(runData.Load(ctx, m.NavigatorRunCommonModel)...)
@@ -54,7 +60,7 @@ func (m NavigatorRunEphemeralResourceModel) Value(ctx context.Context, opts *pro
 }
 
 func (m *NavigatorRunEphemeralResourceModel) Set(ctx context.Context, run navigatorRunData) diag.Diagnostics {
-	return run.Store(ctx, &m.Command, &m.AnsibleOptions, &m.ArtifactQueries)
+	return run.Store(ctx, &m.Command, &m.AnsibleOptions, &m.ArtifactQueries, &m.ArtifactQueryResults, &m.HostStats, &m.TaskResults, &m.Timing, &m.ImageDigest)
 }
 
 type NavigatorRunEphemeralResource struct {
@@ -95,7 +101,7 @@ func (er *NavigatorRunEphemeralResource) Open(ctx context.Context, req ephemeral
 	var data *NavigatorRunEphemeralResourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
-	resp.Diagnostics.Append(data.SetDefaults(ctx)...)
+	resp.Diagnostics.Append(data.SetDefaults(ctx, er.opts.Defaults)...)
 
 	if resp.Diagnostics.HasError() {
 		return
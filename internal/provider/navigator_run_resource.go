@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
@@ -11,10 +12,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible/navigator"
 )
 
 var (
@@ -26,13 +29,23 @@ var (
 type NavigatorRunResourceModel struct {
 	NavigatorRunCommonModel
 
-	RunOnDestroy    types.Bool     `tfsdk:"run_on_destroy"`
-	DestroyPlaybook types.String   `tfsdk:"destroy_playbook"`
-	Triggers        types.Object   `tfsdk:"triggers"`
-	ArtifactQueries types.Map      `tfsdk:"artifact_queries"`
-	ID              types.String   `tfsdk:"id"`
-	Command         types.String   `tfsdk:"command"`
-	Timeouts        timeouts.Value `tfsdk:"timeouts"`
+	RunOnDestroy         types.Bool     `tfsdk:"run_on_destroy"`
+	DestroyPlaybook      types.String   `tfsdk:"destroy_playbook"`
+	Triggers             types.Object   `tfsdk:"triggers"`
+	PlanCheckMode        types.Bool     `tfsdk:"plan_check_mode"`
+	DriftDetection       types.Bool     `tfsdk:"drift_detection"`
+	DriftDetected        types.Bool     `tfsdk:"drift_detected"`
+	ArtifactExport       types.Object   `tfsdk:"artifact_export"`
+	ArtifactExportPath   types.String   `tfsdk:"artifact_export_path"`
+	ArtifactQueries      types.Map      `tfsdk:"artifact_queries"`
+	ArtifactQueryResults types.Dynamic  `tfsdk:"artifact_query_results"`
+	HostStats            types.Map      `tfsdk:"host_stats"`
+	TaskResults          types.List     `tfsdk:"task_results"`
+	Timing               types.Object   `tfsdk:"timing"`
+	ImageDigest          types.String   `tfsdk:"image_digest"`
+	ID                   types.String   `tfsdk:"id"`
+	Command              types.String   `tfsdk:"command"`
+	Timeouts             timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (m NavigatorRunResourceModel) Value(ctx context.Context, destroy bool, opts *providerOptions, runs uint32, previousInventory *string, runData *navigatorRunData) diag.Diagnostics {
@@ -41,6 +54,7 @@ func (m NavigatorRunResourceModel) Value(ctx context.Context, destroy bool, opts
 	*runData = navigatorRunData{
 		hostDir:    navigatorRunDirPath(opts.BaseRunDirectory, m.ID.ValueString(), runs),
 		persistDir: opts.PersistRunDirectory,
+		limiter:    opts.RunLimiter,
 	}
 
 	diags.Append(runData.Load(ctx, m.NavigatorRunCommonModel)...)
@@ -51,6 +65,22 @@ func (m NavigatorRunResourceModel) Value(ctx context.Context, destroy bool, opts
 
 	if previousInventory != nil {
 		runData.config.Inventories = append(runData.config.Inventories, ansible.Inventory{Name: navigatorRunPrevInventoryName, Contents: *previousInventory, Exclude: true})
+		runData.inventoryEnvVars[navigatorRunPrevInventoryEnvVar] = navigatorRunPrevInventoryName
+	}
+
+	exportValue := opts.ArtifactExport
+	if !m.ArtifactExport.IsNull() {
+		exportValue = m.ArtifactExport
+	}
+
+	if !exportValue.IsNull() {
+		var exportModel ArtifactExportModel
+		diags.Append(exportValue.As(ctx, &exportModel, basetypes.ObjectAsOptions{})...)
+
+		// run appends the operation once known, so a destroy never replaces the
+		// export of a create or update with the same run number
+		runData.export = &navigator.ArtifactExport{Name: fmt.Sprintf("%s-%d", m.ID.ValueString(), runs), Group: m.ID.ValueString()}
+		diags.Append(exportModel.Value(ctx, runData.export)...)
 	}
 
 	var queriesModel map[string]ArtifactQueryModel
@@ -69,7 +99,12 @@ func (m NavigatorRunResourceModel) Value(ctx context.Context, destroy bool, opts
 }
 
 func (m *NavigatorRunResourceModel) Set(ctx context.Context, run navigatorRunData) diag.Diagnostics {
-	return run.Store(ctx, &m.Command, &m.AnsibleOptions, &m.ArtifactQueries)
+	m.ArtifactExportPath = types.StringNull()
+	if run.exportPath != "" {
+		m.ArtifactExportPath = types.StringValue(run.exportPath)
+	}
+
+	return run.Store(ctx, &m.Command, &m.AnsibleOptions, &m.ArtifactQueries, &m.ArtifactQueryResults, &m.HostStats, &m.TaskResults, &m.Timing, &m.ImageDigest)
 }
 
 func (m *NavigatorRunResourceModel) Trigger(name string) attr.Value { //nolint:ireturn
@@ -85,15 +120,24 @@ func (m *NavigatorRunResourceModel) ShouldRun(state *NavigatorRunResourceModel)
 		return !m.Trigger("exclusive_run").Equal(state.Trigger("exclusive_run"))
 	}
 
-	// skip working_directory, ansible_navigator_binary, run_on_destroy, destroy_playbook, timeouts
+	// a corrective run for drift found by Read
+	if state.DriftDetected.ValueBool() {
+		return true
+	}
+
+	// skip working_directory, ansible_navigator_binary, ansible_runner, retry, run_on_destroy, destroy_playbook, plan_check_mode, drift_detection, artifact_export, timeouts, cancel_grace_period, redact_values, junit_report_path, timing_slowest_tasks
 	unchanged := []bool{
 		m.Playbook.Equal(state.Playbook),
 		m.Inventory.Equal(state.Inventory),
+		m.Inventories.Equal(state.Inventories),
 		m.ExecutionEnvironment.Equal(state.ExecutionEnvironment),
 		m.AnsibleOptions.Equal(state.AnsibleOptions),
+		m.Requirements.Equal(state.Requirements),
+		m.Files.Equal(state.Files),
 		m.Timezone.Equal(state.Timezone),
 		m.Trigger("run").Equal(state.Trigger("run")),
 		m.ArtifactQueries.Equal(state.ArtifactQueries),
+		m.ImageDigest.Equal(state.ImageDigest),
 	}
 
 	return slices.Contains(unchanged, false)
@@ -117,6 +161,7 @@ func (r *NavigatorRunResource) Schema(ctx context.Context, _ resource.SchemaRequ
 	// TODO include defaultNavigatorRunTimeout in description
 	attributes["timeouts"] = timeouts.Attributes(ctx, timeouts.Opts{
 		Create: true,
+		Read:   true,
 		Update: true,
 		Delete: true,
 	})
@@ -156,16 +201,36 @@ func (r *NavigatorRunResource) ModifyPlan(ctx context.Context, req resource.Modi
 		)
 	}
 
-	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+	if req.Plan.Raw.IsNull() {
 		return
 	}
 
+	// Without a configured provider the plan keeps the schema defaults.
+	if r.opts != nil {
+		var config *NavigatorRunResourceModel
+
+		resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(data.ApplyProviderDefaults(ctx, config.NavigatorRunCommonModel, r.opts.Defaults)...)
+	}
+
 	defer func() {
 		if !resp.Diagnostics.HasError() {
 			resp.Diagnostics.Append(resp.Plan.Set(ctx, &data)...)
 		}
 	}()
 
+	if req.State.Raw.IsNull() {
+		data.DriftDetected = types.BoolValue(false)
+		r.planCheck(ctx, req.Config, &resp.Diagnostics, data, nil)
+
+		return
+	}
+
 	var optsPlanModel, optsStateModel AnsibleOptionsModel
 	resp.Diagnostics.Append(data.AnsibleOptions.As(ctx, &optsPlanModel, basetypes.ObjectAsOptions{})...)
 	resp.Diagnostics.Append(state.AnsibleOptions.As(ctx, &optsStateModel, basetypes.ObjectAsOptions{})...)
@@ -180,13 +245,52 @@ func (r *NavigatorRunResource) ModifyPlan(ctx context.Context, req resource.Modi
 	resp.Diagnostics.Append(newDiags...)
 	data.AnsibleOptions = optsPlanValue
 
+	r.planImageDigest(ctx, &resp.Diagnostics, req.Private.GetKey, data, state)
+
 	if !data.ShouldRun(state) {
 		tflog.Debug(ctx, "planning no run", map[string]any{"reason": "no changes to run for"})
 
+		// state written before these attributes existed has nothing to carry forward
+		if data.HostStats.IsUnknown() {
+			data.HostStats = types.MapNull(types.ObjectType{AttrTypes: HostStatsModel{}.AttrTypes()})
+		}
+
+		if data.TaskResults.IsUnknown() {
+			data.TaskResults = types.ListNull(types.ObjectType{AttrTypes: TaskResultModel{}.AttrTypes()})
+		}
+
+		if data.Timing.IsUnknown() {
+			data.Timing = types.ObjectNull(TimingModel{}.AttrTypes())
+		}
+
+		if data.ImageDigest.IsUnknown() {
+			data.ImageDigest = types.StringNull()
+		}
+
+		if data.ArtifactQueryResults.IsUnknown() {
+			data.ArtifactQueryResults = types.DynamicNull()
+		}
+
+		if data.ArtifactExportPath.IsUnknown() {
+			data.ArtifactExportPath = types.StringNull()
+		}
+
+		data.DriftDetected = state.DriftDetected
+
 		return
 	}
 
+	if state.DriftDetected.ValueBool() {
+		tflog.Debug(ctx, "planning run", map[string]any{"reason": "drift detected"})
+	}
+
 	data.Command = types.StringUnknown()
+	data.ArtifactExportPath = types.StringUnknown()
+	data.DriftDetected = types.BoolValue(false)
+	data.HostStats = types.MapUnknown(types.ObjectType{AttrTypes: HostStatsModel{}.AttrTypes()})
+	data.TaskResults = types.ListUnknown(types.ObjectType{AttrTypes: TaskResultModel{}.AttrTypes()})
+	data.Timing = types.ObjectUnknown(TimingModel{}.AttrTypes())
+	data.ImageDigest = types.StringUnknown()
 
 	var artifactQueriesPlanModel map[string]ArtifactQueryModel
 	resp.Diagnostics.Append(data.ArtifactQueries.ElementsAs(ctx, &artifactQueriesPlanModel, false)...)
@@ -199,6 +303,183 @@ func (r *NavigatorRunResource) ModifyPlan(ctx context.Context, req resource.Modi
 	artifactQueriesPlanValue, newDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: ArtifactQueryModel{}.AttrTypes()}, artifactQueriesPlanModel)
 	resp.Diagnostics.Append(newDiags...)
 	data.ArtifactQueries = artifactQueriesPlanValue
+	data.ArtifactQueryResults = types.DynamicUnknown()
+
+	r.planCheck(ctx, req.Config, &resp.Diagnostics, data, state)
+}
+
+// planImageDigest proposes a run when the image resolved to a different digest
+// when last refreshed, such as a tag that now points to a different image. The
+// image is never inspected while planning: Terraform plans again while
+// applying, and both plans read the same private state, so they always agree.
+func (r *NavigatorRunResource) planImageDigest(ctx context.Context, diags *diag.Diagnostics, getKey getKey, data *NavigatorRunResourceModel, state *NavigatorRunResourceModel) {
+	digest := getRefreshedImageDigest(ctx, diags, getKey)
+	if digest == "" || state.ImageDigest.IsNull() || digest == state.ImageDigest.ValueString() {
+		return
+	}
+
+	tflog.Debug(ctx, "planning run", map[string]any{"reason": "image digest changed", "imageDigest": digest})
+
+	data.ImageDigest = types.StringUnknown()
+}
+
+// inspectImageDigest inspects the image again, for planImageDigest to compare
+// with the digest in state. Without a digest in state there is nothing to
+// compare, and an image that cannot be inspected keeps the digest in state.
+func (r *NavigatorRunResource) inspectImageDigest(ctx context.Context, diags *diag.Diagnostics, data *NavigatorRunResourceModel) string {
+	if r.opts == nil || data.ImageDigest.IsNull() || !data.AnsibleRunner.IsNull() || data.ExecutionEnvironment.IsNull() {
+		return ""
+	}
+
+	var eeModel ExecutionEnvironmentModel
+	diags.Append(data.ExecutionEnvironment.As(ctx, &eeModel, basetypes.ObjectAsOptions{})...)
+
+	if diags.HasError() || !eeModel.Enabled.ValueBool() {
+		return ""
+	}
+
+	digest, err := navigator.InspectImageDigest(ctx, ansible.OSExecutor(), navigator.ContainerEngine(eeModel.ContainerEngine.ValueString()), eeModel.Image.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, "keeping image digest from state", map[string]any{"reason": err.Error()})
+
+		return ""
+	}
+
+	return digest
+}
+
+// planCheck previews a planned run in check mode. Problems with the preview are
+// reported as warnings, it should never be the reason a plan fails. Terraform
+// plans again while applying, and the second preview may predict differently,
+// so predictions are only reported as warnings, never planned as a value the
+// final plan could contradict.
+func (r *NavigatorRunResource) planCheck(ctx context.Context, config tfsdk.Config, diags *diag.Diagnostics, data *NavigatorRunResourceModel, state *NavigatorRunResourceModel) {
+	if !data.PlanCheckMode.ValueBool() {
+		return
+	}
+
+	if !config.Raw.IsFullyKnown() || r.opts == nil {
+		tflog.Debug(ctx, "skipping plan check", map[string]any{"reason": "configuration not fully known"})
+
+		diags.AddWarning(
+			"Plan check skipped",
+			"'plan_check_mode' is enabled, but the configuration contains values that will only be known after apply. "+
+				"The playbook will run without a preview.",
+		)
+
+		return
+	}
+
+	timeoutOperation := terraformOpCreate
+
+	var previousInventory *string
+	if state != nil {
+		timeoutOperation = terraformOpUpdate
+		previousInventory = state.Inventory.ValueStringPointer()
+	}
+
+	changes, ok := r.checkModePreview(ctx, diags, data, terraformOpPlan, timeoutOperation, previousInventory)
+
+	if ok && len(changes) > 0 {
+		diags.AddWarning(
+			"Playbook changes predicted",
+			fmt.Sprintf("Check mode predicts %d changed task(s):\n%s", len(changes), changedTaskDetails(changes)),
+		)
+	}
+}
+
+// detectDrift runs the playbook from state in check mode and records whether
+// any task would change. Like planCheck, problems are reported as warnings, a
+// failed check must not block a refresh.
+func (r *NavigatorRunResource) detectDrift(ctx context.Context, diags *diag.Diagnostics, data *NavigatorRunResourceModel) {
+	changes, ok := r.checkModePreview(ctx, diags, data, terraformOpRead, terraformOpRead, nil)
+	if !ok {
+		return
+	}
+
+	data.DriftDetected = types.BoolValue(len(changes) > 0)
+
+	if len(changes) > 0 {
+		diags.AddWarning(
+			"Configuration drift detected",
+			fmt.Sprintf("Check mode reports %d changed task(s), the next apply will run the playbook:\n%s", len(changes), changedTaskDetails(changes)),
+		)
+	}
+}
+
+// checkModePreview runs the playbook in check mode with the given operation
+// and returns the changed tasks. Run errors become warnings, ok is false when
+// the preview did not complete.
+func (r *NavigatorRunResource) checkModePreview(ctx context.Context, diags *diag.Diagnostics, data *NavigatorRunResourceModel, operation terraformOp, timeoutOperation terraformOp, previousInventory *string) ([]ansible.TaskResult, bool) {
+	timeout, newDiags := terraformOperationResourceTimeout(ctx, timeoutOperation, data.Timeouts, defaultNavigatorRunTimeout)
+	diags.Append(newDiags...)
+
+	if diags.HasError() {
+		return nil, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout+navigatorRunTimeoutOverhead)
+	defer cancel()
+
+	var runData navigatorRunData
+
+	diags.Append(data.Value(ctx, false, r.opts, 0, previousInventory, &runData)...)
+
+	if diags.HasError() {
+		return nil, false
+	}
+
+	// id is unknown on create, and the preview must never share a directory with a real run
+	runData.hostDir = navigatorRunDirPath(r.opts.BaseRunDirectory, uuid.New().String(), 0)
+	runData.operation = operation
+	runData.export = nil
+	runData.junitReportPath = ""
+	runData.playbookArtifactQueries = nil
+	runData.config.Options.Check = true
+	runData.config.Options.Diff = true
+	runData.config.Settings.Timeout = timeout
+
+	var runDiags diag.Diagnostics
+
+	run(ctx, &runDiags, &runData)
+
+	summaryPrefix := "Plan check"
+	if operation == terraformOpRead {
+		summaryPrefix = "Drift detection"
+	}
+
+	for _, runDiag := range runDiags {
+		if runDiag.Severity() == diag.SeverityError {
+			diags.AddWarning(fmt.Sprintf("%s: %s", summaryPrefix, runDiag.Summary()), runDiag.Detail())
+
+			continue
+		}
+
+		diags.Append(runDiag)
+	}
+
+	if runDiags.HasError() {
+		return nil, false
+	}
+
+	var changes []ansible.TaskResult
+
+	for _, result := range runData.taskResults {
+		if result.Changed {
+			changes = append(changes, result)
+		}
+	}
+
+	return changes, true
+}
+
+func changedTaskDetails(changes []ansible.TaskResult) string {
+	details := make([]string, 0, len(changes))
+	for _, result := range changes {
+		details = append(details, fmt.Sprintf("- %s: %s (play: %s)", result.Host, result.Name, result.Play))
+	}
+
+	return strings.Join(details, "\n")
 }
 
 func (r *NavigatorRunResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -252,7 +533,32 @@ func (r *NavigatorRunResource) Create(ctx context.Context, req resource.CreateRe
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func (r *NavigatorRunResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+func (r *NavigatorRunResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data *NavigatorRunResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setRefreshedImageDigest(ctx, &resp.Diagnostics, resp.Private.SetKey, r.inspectImageDigest(ctx, &resp.Diagnostics, data))
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.DriftDetection.ValueBool() || r.opts == nil {
+		return
+	}
+
+	r.detectDrift(ctx, &resp.Diagnostics, data)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *NavigatorRunResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -278,6 +584,7 @@ func (r *NavigatorRunResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	runs := incrementRuns(ctx, &resp.Diagnostics, req.Private.GetKey, resp.Private.SetKey)
+	setRefreshedImageDigest(ctx, &resp.Diagnostics, resp.Private.SetKey, "")
 
 	if resp.Diagnostics.HasError() {
 		return
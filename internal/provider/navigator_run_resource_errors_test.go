@@ -4,6 +4,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -46,6 +47,28 @@ func TestAccNavigatorRunResource_errors_host_key_checking(t *testing.T) {
 	})
 }
 
+func TestAccNavigatorRunResource_errors_ssh_certificate_expired(t *testing.T) {
+	t.Parallel()
+
+	_, caPrivateKey := testSSHKeygen(t)
+	clientPublicKey, clientPrivateKey := testSSHKeygen(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testTerraformConfig(t, filepath.Join("navigator_run_resource", "errors", "ssh_certificate_expired")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"client_private_key_data": config.StringVariable(clientPrivateKey),
+					"client_certificate":      config.StringVariable(testSSHCertificate(t, caPrivateKey, clientPublicKey, -time.Minute)),
+				}),
+				ExpectError: regexp.MustCompile("(?s)SSH certificate of private key 'test' is not(.*)valid(.*)expired(\\s)at"),
+			},
+		},
+	})
+}
+
 func TestAccNavigatorRunResource_errors(t *testing.T) {
 	t.Parallel()
 
@@ -62,6 +85,10 @@ func TestAccNavigatorRunResource_errors(t *testing.T) {
 			name:     "artifact_query_runtime",
 			expected: regexp.MustCompile("Playbook artifact query failed"),
 		},
+		{
+			name:     "cancel_grace_period",
+			expected: regexp.MustCompile("cancel grace period must not be negative"),
+		},
 		{
 			name:     "env_var_name_empty",
 			expected: regexp.MustCompile(`must(\s)not(\s)be(\s)empty`),
@@ -74,13 +101,21 @@ func TestAccNavigatorRunResource_errors(t *testing.T) {
 			name:     "extra_vars_yaml",
 			expected: regexp.MustCompile("Not valid YAML"),
 		},
+		{
+			name:     "files",
+			expected: regexp.MustCompile("project file path must be a relative file path"),
+		},
 		{
 			name:     "image",
 			expected: regexp.MustCompile("failed to parse container image"),
 		},
+		{
+			name:     "inventories",
+			expected: regexp.MustCompile(`must(\s)be(\s)none(\s)of`),
+		},
 		{
 			name:     "known_hosts",
-			expected: regexp.MustCompile("(?s)SSH known host must not be empty(.*)failed to parse SSH known host(.*)must not include multiple"),
+			expected: regexp.MustCompile("(?s)SSH known host must not be empty(.*)failed to parse SSH known host(.*)must not include multiple(.*)marker must be"),
 		},
 		{
 			name: "navigator_preflight",
@@ -101,7 +136,19 @@ func TestAccNavigatorRunResource_errors(t *testing.T) {
 		},
 		{
 			name:     "private_keys",
-			expected: regexp.MustCompile(`(?s)SSH private key must be a(.*)key(\s)must(\s)be(\s)unencrypted(.*)key(\s)name(\s)can(\s)only(\s)contain`),
+			expected: regexp.MustCompile(`(?s)SSH private key must be a(.*)a(\s)passphrase(\s)is(\s)required(.*)key(\s)name(\s)can(\s)only(\s)contain(.*)must(\s)be(\s)an(\s)OpenSSH(\s)certificate(.*)passphrase(\s)must(\s)not(\s)be(\s)set`),
+		},
+		{
+			name:     "requirements",
+			expected: regexp.MustCompile("requirements source is not valid"),
+		},
+		{
+			name:     "require_image_digest",
+			expected: regexp.MustCompile("not pinned by digest"),
+		},
+		{
+			name:     "retry",
+			expected: regexp.MustCompile("failed to parse backoff duration"),
 		},
 		{
 			name:     "timeout",
@@ -115,6 +162,10 @@ func TestAccNavigatorRunResource_errors(t *testing.T) {
 			name:     "timezone_invalid",
 			expected: regexp.MustCompile("IANA time zone not found"),
 		},
+		{
+			name:     "vault_passwords",
+			expected: regexp.MustCompile(`vault(\s)ID(\s)can(\s)only(\s)contain`),
+		},
 		{
 			name: "working_directory",
 			variables: func(t *testing.T) config.Variables { //nolint:thelper
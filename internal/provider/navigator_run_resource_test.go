@@ -2,9 +2,12 @@ package provider_test
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/config"
@@ -12,6 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
 )
@@ -36,12 +40,67 @@ func TestAccNavigatorRunResource_ansible_options(t *testing.T) {
 						tfjsonpath.New("command"),
 						knownvalue.StringRegexp(regexp.MustCompile("--force-handlers --skip-tags tag1,tag2 --start-at-task task name --limit host1,host2 --tags tag3,tag4")),
 					),
+					statecheck.ExpectKnownValue(
+						navigatorRunResource,
+						tfjsonpath.New("image_digest"),
+						knownvalue.StringRegexp(regexp.MustCompile("^sha256:[0-9a-f]{64}$")),
+					),
 				},
 			},
 		},
 	})
 }
 
+func TestAccNavigatorRunResource_artifact_export(t *testing.T) {
+	t.Parallel()
+
+	exportDir := filepath.Join(t.TempDir(), "exports")
+	exportPath := tfjsonpath.New("artifact_export_path")
+
+	// retain keeps the files of one run, the artifact and the log
+	exportedFiles := func(*terraform.State) error {
+		entries, err := os.ReadDir(exportDir)
+		if err != nil {
+			return err
+		}
+
+		if len(entries) != 2 {
+			return fmt.Errorf("expected the files of 1 run to be retained, got %d files", len(entries))
+		}
+
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testTerraformConfig(t, filepath.Join("navigator_run_resource", "artifact_export")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"export_directory": config.StringVariable(exportDir),
+					"run":              config.StringVariable(testString),
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(navigatorRunResource, exportPath, knownvalue.StringRegexp(regexp.MustCompile(`-1-create\.playbook-artifact\.json\.gz$`))),
+				},
+				Check: exportedFiles,
+			},
+			{
+				Config: testTerraformConfig(t, filepath.Join("navigator_run_resource", "artifact_export")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"export_directory": config.StringVariable(exportDir),
+					"run":              config.StringVariable(testUpdateString),
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(navigatorRunResource, exportPath, knownvalue.StringRegexp(regexp.MustCompile(`-2-update\.playbook-artifact\.json\.gz$`))),
+				},
+				Check: exportedFiles,
+			},
+		},
+	})
+}
+
 func TestAccNavigatorRunResource_artifact_queries(t *testing.T) {
 	t.Parallel()
 
@@ -63,7 +122,18 @@ func TestAccNavigatorRunResource_artifact_queries(t *testing.T) {
 						tfjsonpath.New("artifact_queries").AtMapKey("stdout").AtMapKey("results").AtSliceIndex(0),
 						knownvalue.StringRegexp(regexp.MustCompile("ok=2")),
 					),
+					statecheck.ExpectKnownValue(
+						navigatorRunResource,
+						tfjsonpath.New("artifact_query_results").AtMapKey("task_names"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("Write file"), knownvalue.StringExact("Get file")}),
+					),
+					statecheck.ExpectKnownValue(
+						navigatorRunResource,
+						tfjsonpath.New("timing").AtMapKey("slowest_tasks"),
+						knownvalue.ListSizeExact(1),
+					),
 					statecheck.ExpectKnownOutputValue("file_contents", knownvalue.StringExact(testString)),
+					statecheck.ExpectKnownOutputValue("file_contents_result", knownvalue.StringExact(testString)),
 				},
 			},
 			{
@@ -84,7 +154,13 @@ func TestAccNavigatorRunResource_artifact_queries(t *testing.T) {
 						tfjsonpath.New("artifact_queries").AtMapKey("stdout").AtMapKey("results").AtSliceIndex(0),
 						knownvalue.StringRegexp(regexp.MustCompile("ok=2")),
 					),
+					statecheck.ExpectKnownValue(
+						navigatorRunResource,
+						tfjsonpath.New("artifact_query_results").AtMapKey("task_names"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("Write file"), knownvalue.StringExact("Get file")}),
+					),
 					statecheck.ExpectKnownOutputValue("file_contents", knownvalue.StringExact(testUpdateString)),
+					statecheck.ExpectKnownOutputValue("file_contents_result", knownvalue.StringExact(testUpdateString)),
 				},
 			},
 		},
@@ -111,7 +187,13 @@ func TestAccNavigatorRunResource_basic(t *testing.T) {
 					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("timezone"), knownvalue.NotNull()),
 					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("run_on_destroy"), knownvalue.NotNull()),
 					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("triggers"), knownvalue.Null()),
+					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("plan_check_mode"), knownvalue.Bool(false)),
 					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("artifact_queries"), knownvalue.Null()),
+					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("host_stats").AtMapKey("local_container").AtMapKey("ok"), knownvalue.Int64Exact(2)),
+					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("host_stats").AtMapKey("local_container").AtMapKey("failed"), knownvalue.Int64Exact(0)),
+					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("task_results"), knownvalue.ListSizeExact(2)),
+					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("task_results").AtSliceIndex(1).AtMapKey("action"), knownvalue.StringExact("ansible.builtin.assert")),
+					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("task_results").AtSliceIndex(1).AtMapKey("status"), knownvalue.StringExact("ok")),
 					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("id"), knownvalue.NotNull()),
 					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("command"), knownvalue.NotNull()),
 					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("timeouts"), knownvalue.Null()),
@@ -147,6 +229,35 @@ func TestAccNavigatorRunResource_binary_in_path(t *testing.T) { //nolint:paralle
 	})
 }
 
+func TestAccNavigatorRunResource_ansible_runner(t *testing.T) { //nolint:paralleltest
+	testPrependPlaybookToPath(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testTerraformConfig(t, filepath.Join("navigator_run_resource", "ansible_runner")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"ansible_runner_binary": config.StringVariable(runnerProgramPath),
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						navigatorRunResource,
+						tfjsonpath.New("artifact_queries").AtMapKey("task").AtMapKey("results"),
+						knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact(`"Check environment"`)}),
+					),
+					statecheck.ExpectKnownValue(
+						navigatorRunResource,
+						tfjsonpath.New("host_stats").AtMapKey("localhost").AtMapKey("ok"),
+						knownvalue.Int64Exact(1),
+					),
+				},
+			},
+		},
+	})
+}
+
 func TestAccNavigatorRunResource_destroy_playbook(t *testing.T) {
 	t.Parallel()
 
@@ -162,6 +273,56 @@ func TestAccNavigatorRunResource_destroy_playbook(t *testing.T) {
 	})
 }
 
+func TestAccNavigatorRunResource_drift_detection(t *testing.T) { //nolint:paralleltest
+	testPrependPlaybookToPath(t)
+
+	filePath := filepath.Join(t.TempDir(), "drift-detection")
+	variables := testConfigVariables(t, config.Variables{
+		"file_path":     config.StringVariable(filePath),
+		"file_contents": config.StringVariable(testString),
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:          testTerraformConfig(t, filepath.Join("navigator_run_resource", "drift_detection")),
+				ConfigVariables: variables,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("drift_detected"), knownvalue.Bool(false)),
+				},
+			},
+			{
+				Config:          testTerraformConfig(t, filepath.Join("navigator_run_resource", "drift_detection")),
+				ConfigVariables: variables,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				PreConfig: func() {
+					if err := os.WriteFile(filePath, []byte(testUpdateString), 0o600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:          testTerraformConfig(t, filepath.Join("navigator_run_resource", "drift_detection")),
+				ConfigVariables: variables,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(navigatorRunResource, plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(navigatorRunResource, tfjsonpath.New("drift_detected"), knownvalue.Bool(false)),
+				},
+			},
+		},
+	})
+}
+
 func TestAccNavigatorRunResource_ee_disabled(t *testing.T) { //nolint:paralleltest
 	testPrependPlaybookToPath(t)
 
@@ -221,6 +382,68 @@ func TestAccNavigatorRunResource_extra_vars(t *testing.T) {
 	})
 }
 
+func TestAccNavigatorRunResource_redact_values(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testTerraformConfig(t, filepath.Join("navigator_run_resource", "redact_values")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"secret": config.StringVariable("s3cr3t-value"),
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						navigatorRunResource,
+						tfjsonpath.New("artifact_queries").AtMapKey("stdout").AtMapKey("results").AtSliceIndex(0),
+						knownvalue.StringRegexp(regexp.MustCompile(`secret is \(redacted\)`)),
+					),
+					statecheck.ExpectKnownValue(
+						navigatorRunResource,
+						tfjsonpath.New("artifact_query_results").AtMapKey("msg"),
+						knownvalue.StringExact("secret is (redacted)"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccNavigatorRunResource_junit_report(t *testing.T) {
+	t.Parallel()
+
+	reportPath := filepath.Join(t.TempDir(), "reports", "junit.xml")
+
+	junitReport := func(*terraform.State) error {
+		contents, err := os.ReadFile(reportPath) //nolint:gosec
+		if err != nil {
+			return err
+		}
+
+		if want := `<testsuite name="Test" tests="2" failures="0" errors="0" skipped="1"`; !strings.Contains(string(contents), want) {
+			return fmt.Errorf("expected JUnit report to contain %s, got:\n%s", want, contents)
+		}
+
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testTerraformConfig(t, filepath.Join("navigator_run_resource", "junit_report")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"junit_report_path": config.StringVariable(reportPath),
+				}),
+				Check: junitReport,
+			},
+		},
+	})
+}
+
 func TestAccNavigatorRunResource_known_hosts(t *testing.T) {
 	t.Parallel()
 
@@ -248,6 +471,50 @@ func TestAccNavigatorRunResource_known_hosts(t *testing.T) {
 	})
 }
 
+func TestAccNavigatorRunResource_plan_check_mode(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testTerraformConfig(t, filepath.Join("navigator_run_resource", "plan_check_mode")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"file_contents": config.StringVariable(testString),
+				}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(navigatorRunResource, plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			{
+				Config: testTerraformConfig(t, filepath.Join("navigator_run_resource", "plan_check_mode")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"file_contents": config.StringVariable(testString),
+				}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: testTerraformConfig(t, filepath.Join("navigator_run_resource", "plan_check_mode")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"file_contents": config.StringVariable(testUpdateString),
+				}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(navigatorRunResource, plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+		},
+	})
+}
+
 func TestAccNavigatorRunResource_previous_inventory(t *testing.T) { //nolint:paralleltest
 	for _, test := range EETestCases() { //nolint:paralleltest
 		t.Run(test.name, func(t *testing.T) {
@@ -293,7 +560,99 @@ func TestAccNavigatorRunResource_previous_inventory(t *testing.T) { //nolint:par
 	}
 }
 
+func TestAccNavigatorRunResource_inventories(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:          testTerraformConfig(t, filepath.Join("navigator_run_resource", "inventories")),
+				ConfigVariables: testDefaultConfigVariables(t),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						navigatorRunResource,
+						tfjsonpath.New("host_stats"),
+						knownvalue.MapSizeExact(2),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccNavigatorRunResource_requirements(t *testing.T) { //nolint:paralleltest
+	for _, test := range EETestCases() { //nolint:paralleltest
+		t.Run(test.name, func(t *testing.T) {
+			test.setup(t)
+
+			variables := config.Variables{}
+			if test.variables != nil {
+				variables = test.variables(t)
+			}
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:          testTerraformConfig(t, filepath.Join("navigator_run_resource", "requirements")),
+						ConfigVariables: testConfigVariables(t, variables),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccNavigatorRunResource_files(t *testing.T) { //nolint:paralleltest
+	for _, test := range EETestCases() { //nolint:paralleltest
+		t.Run(test.name, func(t *testing.T) {
+			test.setup(t)
+
+			variables := config.Variables{}
+			if test.variables != nil {
+				variables = test.variables(t)
+			}
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:          testTerraformConfig(t, filepath.Join("navigator_run_resource", "files")),
+						ConfigVariables: testConfigVariables(t, variables),
+					},
+				},
+			})
+		})
+	}
+}
+
 //nolint:dupl
+func TestAccNavigatorRunResource_retry(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:          testTerraformConfig(t, filepath.Join("navigator_run_resource", "retry")),
+				ConfigVariables: testDefaultConfigVariables(t),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						navigatorRunResource,
+						tfjsonpath.New("host_stats").AtMapKey("localhost").AtMapKey("failed"),
+						knownvalue.Int64Exact(0),
+					),
+				},
+			},
+		},
+	})
+}
+
 func TestAccNavigatorRunResource_private_keys(t *testing.T) { //nolint:paralleltest
 	for _, test := range EETestCases() { //nolint:paralleltest
 		t.Run(test.name, func(t *testing.T) {
@@ -333,6 +692,158 @@ func TestAccNavigatorRunResource_private_keys(t *testing.T) { //nolint:parallelt
 	}
 }
 
+func TestAccNavigatorRunResource_ssh_agent(t *testing.T) { //nolint:paralleltest
+	for _, test := range EETestCases() { //nolint:paralleltest
+		t.Run(test.name, func(t *testing.T) {
+			test.setup(t)
+
+			variables := config.Variables{}
+			if test.variables != nil {
+				variables = test.variables(t)
+			}
+
+			clientPublicKey, clientPrivateKey := testSSHKeygen(t)
+			serverPublicKey, serverPrivateKey := testSSHKeygen(t)
+			port := testSSHServer(t, clientPublicKey, serverPrivateKey)
+
+			variables["client_private_key_data"] = config.StringVariable(clientPrivateKey)
+			variables["server_public_key_data"] = config.StringVariable(serverPublicKey)
+			variables["ssh_port"] = config.IntegerVariable(port)
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:          testTerraformConfig(t, filepath.Join("navigator_run_resource", "ssh_agent")),
+						ConfigVariables: testConfigVariables(t, variables),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccNavigatorRunResource_private_key_passphrase(t *testing.T) { //nolint:paralleltest
+	for _, test := range EETestCases() { //nolint:paralleltest
+		t.Run(test.name, func(t *testing.T) {
+			test.setup(t)
+
+			variables := config.Variables{}
+			if test.variables != nil {
+				variables = test.variables(t)
+			}
+
+			clientPublicKey, clientPrivateKey := testSSHKeygenWithPassphrase(t, "secret")
+			serverPublicKey, serverPrivateKey := testSSHKeygen(t)
+			port := testSSHServer(t, clientPublicKey, serverPrivateKey)
+
+			variables["client_private_key_data"] = config.StringVariable(clientPrivateKey)
+			variables["client_private_key_passphrase"] = config.StringVariable("secret")
+			variables["server_public_key_data"] = config.StringVariable(serverPublicKey)
+			variables["ssh_port"] = config.IntegerVariable(port)
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:          testTerraformConfig(t, filepath.Join("navigator_run_resource", "private_key_passphrase")),
+						ConfigVariables: testConfigVariables(t, variables),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccNavigatorRunResource_ssh_proxy(t *testing.T) { //nolint:paralleltest
+	for _, test := range EETestCases() { //nolint:paralleltest
+		t.Run(test.name, func(t *testing.T) {
+			test.setup(t)
+
+			variables := config.Variables{}
+			if test.variables != nil {
+				variables = test.variables(t)
+			}
+
+			clientPublicKey, clientPrivateKey := testSSHKeygen(t)
+			serverPublicKey, serverPrivateKey := testSSHKeygen(t)
+			port := testSSHServer(t, clientPublicKey, serverPrivateKey)
+
+			bastionClientPublicKey, bastionClientPrivateKey := testSSHKeygen(t)
+			bastionPublicKey, bastionPrivateKey := testSSHKeygen(t)
+			bastionPort := testSSHProxyServer(t, bastionClientPublicKey, bastionPrivateKey)
+
+			variables["client_private_key_data"] = config.StringVariable(clientPrivateKey)
+			variables["server_public_key_data"] = config.StringVariable(serverPublicKey)
+			variables["ssh_port"] = config.IntegerVariable(port)
+			variables["bastion_private_key_data"] = config.StringVariable(bastionClientPrivateKey)
+			variables["bastion_public_key_data"] = config.StringVariable(bastionPublicKey)
+			variables["bastion_port"] = config.IntegerVariable(bastionPort)
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:          testTerraformConfig(t, filepath.Join("navigator_run_resource", "ssh_proxy")),
+						ConfigVariables: testConfigVariables(t, variables),
+						ConfigStateChecks: []statecheck.StateCheck{
+							statecheck.ExpectKnownValue(
+								navigatorRunResource,
+								tfjsonpath.New("command"),
+								knownvalue.StringRegexp(regexp.MustCompile(fmt.Sprintf("--extra-vars %s=", ansible.SSHProxyConfigFileVar))),
+							),
+						},
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccNavigatorRunResource_ssh_certificate(t *testing.T) { //nolint:paralleltest
+	for _, test := range EETestCases() { //nolint:paralleltest
+		t.Run(test.name, func(t *testing.T) {
+			test.setup(t)
+
+			variables := config.Variables{}
+			if test.variables != nil {
+				variables = test.variables(t)
+			}
+
+			caPublicKey, caPrivateKey := testSSHKeygen(t)
+			clientPublicKey, clientPrivateKey := testSSHKeygen(t)
+			_, serverPrivateKey := testSSHKeygen(t)
+			port := testSSHCertificateServer(t, caPrivateKey, serverPrivateKey)
+
+			variables["client_private_key_data"] = config.StringVariable(clientPrivateKey)
+			variables["client_certificate"] = config.StringVariable(testSSHCertificate(t, caPrivateKey, clientPublicKey, time.Hour))
+			variables["ca_public_key_data"] = config.StringVariable(caPublicKey)
+			variables["ssh_port"] = config.IntegerVariable(port)
+
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:          testTerraformConfig(t, filepath.Join("navigator_run_resource", "ssh_certificate")),
+						ConfigVariables: testConfigVariables(t, variables),
+						ConfigStateChecks: []statecheck.StateCheck{
+							statecheck.ExpectKnownValue(
+								navigatorRunResource,
+								tfjsonpath.New("ansible_options").AtMapKey("known_hosts").AtSliceIndex(0),
+								knownvalue.StringExact(fmt.Sprintf("@cert-authority [127.0.0.1]:%d %s", port, caPublicKey)),
+							),
+						},
+					},
+				},
+			})
+		})
+	}
+}
+
 func TestAccNavigatorRunResource_pull_args(t *testing.T) {
 	t.Parallel()
 
@@ -616,3 +1127,33 @@ func TestAccNavigatorRunResource_trigger_run(t *testing.T) {
 		},
 	})
 }
+
+func TestAccNavigatorRunResource_vault_passwords(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testTerraformConfig(t, filepath.Join("navigator_run_resource", "vault_passwords")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"secret":        config.StringVariable(testString),
+					"default_vault": config.StringVariable(testVaultEncrypt(t, "default", "default-password", testString)),
+					"prod_vault":    config.StringVariable(testVaultEncrypt(t, "prod", "prod-password", testString)),
+					"vault_passwords": config.MapVariable(map[string]config.Variable{
+						"default": config.StringVariable("default-password"),
+						"prod":    config.StringVariable("prod-password"),
+					}),
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						navigatorRunResource,
+						tfjsonpath.New("command"),
+						knownvalue.StringRegexp(regexp.MustCompile("--vault-id default@.+ --vault-id prod@.+")),
+					),
+				},
+			},
+		},
+	})
+}
@@ -4,13 +4,17 @@ import (
 	"maps"
 
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/dynamicplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -38,7 +42,7 @@ func playbookDescription() attrDescription {
 }
 
 func inventoryDescription(target surface) attrDescription {
-	description := describe("Ansible [inventory](https://docs.ansible.com/ansible/latest/getting_started/get_started_inventory.html) contents. The environment variable `%s` is set to the path of the inventory in cases where `{{ inventory_file }}` cannot be referenced.", navigatorRunInventoryEnvVar)
+	description := describe("Ansible [inventory](https://docs.ansible.com/ansible/latest/getting_started/get_started_inventory.html) contents. At least one of `inventory` or `inventories` must be set. The environment variable `%s` is set to the path of the inventory in cases where `{{ inventory_file }}` cannot be referenced.", navigatorRunInventoryEnvVar)
 
 	if target != surfaceResource {
 		return description
@@ -47,6 +51,10 @@ func inventoryDescription(target surface) attrDescription {
 	return description.append("In addition, the environment variable `%s` is set to the path of the last applied inventory when the resource is updated.", navigatorRunPrevInventoryEnvVar)
 }
 
+func inventoriesDescription() attrDescription {
+	return describe("Additional Ansible inventory sources keyed by file name, such as static inventories or [inventory plugin](https://docs.ansible.com/ansible/latest/plugins/inventory.html) configuration files (e.g. `prod.aws_ec2.yml`, `constructed.yml`). Sources are passed to Ansible after `inventory`, in lexical order of their names. The environment variable `%s_<NAME>` is set to the path of each source, where `<NAME>` is the name in upper case with characters other than letters and numbers replaced by `_`.", navigatorRunInventoryEnvVar)
+}
+
 func environmentVariablesSetDescription(target surface) attrDescription {
 	description := describe("Environment variables to be [set](https://ansible.readthedocs.io/projects/navigator/settings/#set-environment-variable) within the execution environment.")
 
@@ -54,7 +62,7 @@ func environmentVariablesSetDescription(target surface) attrDescription {
 
 	switch target {
 	case surfaceResource:
-		return description.append("`%s` is automatically set to the current CRUD operation (%s).", navigatorRunOperationEnvVar, wrapElementsJoin(terraformOps{terraformOpCreate, terraformOpUpdate, terraformOpDelete}.Strings(), "`"))
+		return description.append("`%s` is automatically set to the current CRUD operation (%s), `%s` during a `plan_check_mode` run, or `%s` during a `drift_detection` check.", navigatorRunOperationEnvVar, wrapElementsJoin(terraformOps{terraformOpCreate, terraformOpUpdate, terraformOpDelete}.Strings(), "`"), terraformOpPlan, terraformOpRead)
 	case surfaceDataSource:
 	case surfaceEphemeral:
 		operation = terraformOpOpen
@@ -65,15 +73,46 @@ func environmentVariablesSetDescription(target surface) attrDescription {
 	return description.append("`%s` is automatically set to `%s`.", navigatorRunOperationEnvVar, operation)
 }
 
+func imageDigestDescription(target surface) attrDescription {
+	description := describe("Repository digest (`sha256:...`) of the execution environment container image used by the run, as reported by the container engine (`image inspect`). Records which image a floating tag resolved to. Null when not running within an execution environment, or when the image cannot be inspected or has no repository digest, such as when built locally.")
+
+	if target != surfaceResource {
+		return description
+	}
+
+	return description.append("The image is inspected again when refreshing, and a different digest proposes a new run.")
+}
+
+func timingSlowestTasksDescription(target surface) attrDescription {
+	if target == surfaceAction {
+		return describe("Number of the slowest tasks logged at debug level after each run. Defaults to `%d`.", defaultNavigatorRunTimingSlowestTasks)
+	}
+
+	return describe("Number of the slowest tasks recorded in `timing.slowest_tasks` and logged at debug level after each run. Defaults to `%d`.", defaultNavigatorRunTimingSlowestTasks)
+}
+
 func navigatorRunAttributes(target surface) map[string]schema.Attribute {
 	descriptions := map[string]attrDescription{
 		"playbook":                 playbookDescription(),
 		"working_directory":        describe("Directory in which `%s` runs. Recommended to be the root Ansible [content directory](https://docs.ansible.com/ansible/latest/tips_tricks/sample_setup.html#sample-directory-layout) (sometimes called the project directory), which is likely to contain `ansible.cfg`, `roles/`, etc. Defaults to `%s`.", navigator.Program, defaultNavigatorRunWorkingDir),
 		"execution_environment":    describe("[Execution environment](https://ansible.readthedocs.io/en/latest/getting_started_ee/index.html) (EE) related configuration."),
 		"ansible_navigator_binary": describe("Path to the `%s` binary. By default `$PATH` is searched.", navigator.Program),
+		"ansible_runner":           describe("Run the playbook with [`%s`](https://docs.ansible.com/projects/runner/en/latest/) directly instead of `%s`, for hosts that only have `ansible-core` and `%s` installed. `%s` must be in `$PATH`. The `execution_environment` and `ansible_navigator_binary` attributes are ignored.", ansible.RunnerProgram, navigator.Program, ansible.RunnerProgram, ansible.PlaybookProgram),
 		"ansible_options":          describe("Ansible [playbook](https://docs.ansible.com/ansible/latest/cli/ansible-playbook.html) run related configuration."),
+		"requirements":             describe("Ansible [Galaxy](https://docs.ansible.com/ansible/latest/galaxy/user_guide.html) content installed with `%s` into the run directory before the playbook runs, within the execution environment when enabled. The environment variables `%s` and `%s` are set to the install locations followed by the Ansible defaults, and take precedence over paths configured in `ansible.cfg`.", ansible.GalaxyProgram, ansible.CollectionsPathEnvVar, ansible.RolesPathEnvVar),
+		"files":                    describe("Auxiliary project files, such as roles, templates and `group_vars`, keyed by path relative to the project directory. Written into a `project/` directory within the run directory, and when set the playbook is written there as well, so paths within the playbook resolve relative to these files, both on the host and within the execution environment. Paths must not leave the project directory."),
+		"retry":                    describe("Retry the playbook run when it fails, such as when hosts are not yet reachable over SSH shortly after boot. Each attempt starts with a fresh playbook artifact, and `host_stats` and `task_results` reflect the last attempt. All attempts, including backoff between them, must complete within the operation timeout."),
 		"timezone":                 describe("IANA time zone, use `local` for the system time zone. Defaults to `%s`.", defaultNavigatorRunTimezone),
+		"cancel_grace_period":      describe("How long the playbook run has to stop once interrupted, such as by `Ctrl-C` or the operation timeout, before it is killed. `%s` is sent an interrupt signal, so it can save the playbook artifact and the tasks completed so far can be reported. The grace period is taken out of the operation timeout, at most half the time remaining, so a run that times out is interrupted that long before the timeout and stopped by it. `0s` kills it straight away. Defaults to `%s`.", navigator.Program, defaultNavigatorRunCancelGracePeriod),
+		"redact_values":            describe("Values masked as `%s` in playbook output, the `command` attribute, artifact query results and provider logs, such as secrets passed in `extra_vars`. Private keys, passphrases, vault passwords and `environment_variables_set` values with names containing `SECRET`, `TOKEN`, `PASSWORD` or similar are always masked. Values must be at least %d characters long, and a warning is reported for other sensitive values too short to be masked.", ansible.Redacted, ansible.MinRedactLength),
+		"timing_slowest_tasks":     timingSlowestTasksDescription(target),
+		"junit_report_path":        describe("Write a JUnit XML report of the playbook run to this path, for CI systems. Each task on each host is a test case, failed tasks are failures, unreachable hosts are errors and skipped tasks are skipped. Written after every run, including failed runs, and outside the run directory so it is kept. Relative paths are relative to the Terraform working directory. Parent directories are created when missing."),
 		"artifact_queries":         describe("Query the Ansible playbook artifact with [`jq`](https://jqlang.github.io/jq/) syntax. The [playbook artifact](https://access.redhat.com/documentation/en-us/red_hat_ansible_automation_platform/2.0-ea/html/ansible_navigator_creator_guide/assembly-troubleshooting-navigator_ansible-navigator#proc-review-artifact_troubleshooting-navigator) contains detailed information about every play and task, as well as the stdout from the playbook run."),
+		"artifact_query_results":   describe("Results of each artifact query, keyed by query name. Unlike `artifact_queries.results`, the JSON is decoded, so no `jsondecode()` is needed. A single value when the filter yields one result, a tuple otherwise."),
+		"host_stats":               describe("Per-host task counters from the playbook run, as reported in the `PLAY RECAP`."),
+		"task_results":             describe("Result of each task on each host, in the order the tasks ran."),
+		"image_digest":             imageDigestDescription(target),
+		"timing":                   describe("Task durations from the start and end timestamps of the playbook artifact job events, for tracking slow roles and tasks. The slowest tasks and the wall time are also logged at debug level."),
 		"id":                       describe("UUID."),
 		"command":                  describe("Generated `%s` run command. Useful for troubleshooting.", navigator.Program),
 	}
@@ -91,9 +130,23 @@ func navigatorRunAttributes(target surface) map[string]schema.Attribute {
 		"inventory": schema.StringAttribute{
 			Description:         inventoryDescription(target).Description,
 			MarkdownDescription: inventoryDescription(target).MarkdownDescription,
-			Required:            true,
+			Optional:            true,
 			Validators: []validator.String{
 				stringvalidator.LengthAtLeast(1),
+				stringvalidator.AtLeastOneOf(path.MatchRoot("inventories")),
+			},
+		},
+		"inventories": schema.MapAttribute{
+			Description:         inventoriesDescription().Description,
+			MarkdownDescription: inventoriesDescription().MarkdownDescription,
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				mapvalidator.KeysAre(
+					stringIsInventoryName(),
+					stringvalidator.NoneOf(navigatorRunName, navigatorRunPrevInventoryName),
+				),
+				mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
 			},
 		},
 		"working_directory": schema.StringAttribute{
@@ -113,6 +166,9 @@ func navigatorRunAttributes(target surface) map[string]schema.Attribute {
 			Computed:            target.allowsComputed(),
 			Default:             target.objectDefault(ExecutionEnvironmentModel{}.Defaults()),
 			Attributes:          executionEnvironmentAttributes(target),
+			Validators: []validator.Object{
+				objectIsPinnedImage(),
+			},
 		},
 		"ansible_navigator_binary": schema.StringAttribute{
 			Description:         descriptions["ansible_navigator_binary"].Description,
@@ -122,6 +178,12 @@ func navigatorRunAttributes(target surface) map[string]schema.Attribute {
 				stringvalidator.LengthAtLeast(1),
 			},
 		},
+		"ansible_runner": schema.SingleNestedAttribute{
+			Description:         descriptions["ansible_runner"].Description,
+			MarkdownDescription: descriptions["ansible_runner"].MarkdownDescription,
+			Optional:            true,
+			Attributes:          ansibleRunnerAttributes(),
+		},
 		"ansible_options": schema.SingleNestedAttribute{
 			Description:         descriptions["ansible_options"].Description,
 			MarkdownDescription: descriptions["ansible_options"].MarkdownDescription,
@@ -130,6 +192,30 @@ func navigatorRunAttributes(target surface) map[string]schema.Attribute {
 			Default:             target.objectDefault(AnsibleOptionsModel{}.Defaults()),
 			Attributes:          ansibleOptionsAttributes(target),
 		},
+		"requirements": schema.SingleNestedAttribute{
+			Description:         descriptions["requirements"].Description,
+			MarkdownDescription: descriptions["requirements"].MarkdownDescription,
+			Optional:            true,
+			Attributes:          requirementsAttributes(),
+		},
+		"files": schema.MapNestedAttribute{
+			Description:         descriptions["files"].Description,
+			MarkdownDescription: descriptions["files"].MarkdownDescription,
+			Optional:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: projectFileAttributes(),
+			},
+			Validators: []validator.Map{
+				mapvalidator.SizeAtLeast(1),
+				mapvalidator.KeysAre(stringIsProjectFilePath()),
+			},
+		},
+		"retry": schema.SingleNestedAttribute{
+			Description:         descriptions["retry"].Description,
+			MarkdownDescription: descriptions["retry"].MarkdownDescription,
+			Optional:            true,
+			Attributes:          retryAttributes(),
+		},
 		"timezone": schema.StringAttribute{
 			Description:         descriptions["timezone"].Description,
 			MarkdownDescription: descriptions["timezone"].MarkdownDescription,
@@ -140,6 +226,43 @@ func navigatorRunAttributes(target surface) map[string]schema.Attribute {
 				stringIsIANATimezone(),
 			},
 		},
+		"cancel_grace_period": schema.StringAttribute{
+			Description:         descriptions["cancel_grace_period"].Description,
+			MarkdownDescription: descriptions["cancel_grace_period"].MarkdownDescription,
+			Optional:            true,
+			Computed:            target.allowsComputed(),
+			Default:             target.stringDefault(defaultNavigatorRunCancelGracePeriod.String()),
+			Validators: []validator.String{
+				stringIsCancelGracePeriod(),
+			},
+		},
+		"timing_slowest_tasks": schema.Int64Attribute{
+			Description:         descriptions["timing_slowest_tasks"].Description,
+			MarkdownDescription: descriptions["timing_slowest_tasks"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		},
+		"junit_report_path": schema.StringAttribute{
+			Description:         descriptions["junit_report_path"].Description,
+			MarkdownDescription: descriptions["junit_report_path"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"redact_values": schema.ListAttribute{
+			Description:         descriptions["redact_values"].Description,
+			MarkdownDescription: descriptions["redact_values"].MarkdownDescription,
+			Optional:            true,
+			Sensitive:           target.allowsSensitive(),
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(ansible.MinRedactLength)),
+			},
+		},
 	}
 
 	if target == surfaceResource {
@@ -157,6 +280,53 @@ func navigatorRunAttributes(target surface) map[string]schema.Attribute {
 					Attributes: artifactQueryAttributes(),
 				},
 			},
+			"artifact_query_results": schema.DynamicAttribute{
+				Description:         descriptions["artifact_query_results"].Description,
+				MarkdownDescription: descriptions["artifact_query_results"].MarkdownDescription,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Dynamic{
+					dynamicplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"host_stats": schema.MapNestedAttribute{
+				Description:         descriptions["host_stats"].Description,
+				MarkdownDescription: descriptions["host_stats"].MarkdownDescription,
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: hostStatsAttributes(),
+				},
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"task_results": schema.ListNestedAttribute{
+				Description:         descriptions["task_results"].Description,
+				MarkdownDescription: descriptions["task_results"].MarkdownDescription,
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: taskResultAttributes(),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"timing": schema.SingleNestedAttribute{
+				Description:         descriptions["timing"].Description,
+				MarkdownDescription: descriptions["timing"].MarkdownDescription,
+				Computed:            true,
+				Attributes:          timingAttributes(),
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"image_digest": schema.StringAttribute{
+				Description:         descriptions["image_digest"].Description,
+				MarkdownDescription: descriptions["image_digest"].MarkdownDescription,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"id": schema.StringAttribute{
 				Description:         descriptions["id"].Description,
 				MarkdownDescription: descriptions["id"].MarkdownDescription,
@@ -188,6 +358,7 @@ func executionEnvironmentAttributes(target surface) map[string]schema.Attribute
 		"pull_arguments":             describe("Additional [parameters](https://ansible.readthedocs.io/projects/navigator/settings/#pull-arguments) that should be added to the pull command when pulling an execution environment container image from a container registry."),
 		"pull_policy":                describe("Container image [pull policy](https://ansible.readthedocs.io/projects/navigator/settings/#pull-policy). Defaults to `%s`.", defaultNavigatorRunPullPolicy),
 		"container_options":          describe("[Extra parameters](https://ansible.readthedocs.io/projects/navigator/settings/#container-options) passed to the container engine command."),
+		"require_image_digest":       describe("Require `image` to be pinned by digest (e.g. `image@sha256:...`), so that every run uses the same image. Checked when validating the configuration and again by the preflight checks. Defaults to `%t`.", defaultNavigatorRunRequireImageDigest),
 	}
 
 	return map[string]schema.Attribute{
@@ -212,6 +383,7 @@ func executionEnvironmentAttributes(target surface) map[string]schema.Attribute
 			Description:         descriptions["environment_variables_pass"].Description,
 			MarkdownDescription: descriptions["environment_variables_pass"].MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
 			ElementType:         types.StringType,
 			Validators: []validator.List{
 				listvalidator.ValueStringsAre(stringIsEnvVarName()),
@@ -221,6 +393,7 @@ func executionEnvironmentAttributes(target surface) map[string]schema.Attribute
 			Description:         environmentVariablesSetDescription(target).Description,
 			MarkdownDescription: environmentVariablesSetDescription(target).MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
 			ElementType:         types.StringType,
 			Validators: []validator.Map{
 				mapvalidator.KeysAre(stringIsEnvVarName()),
@@ -240,6 +413,7 @@ func executionEnvironmentAttributes(target surface) map[string]schema.Attribute
 			Description:         descriptions["pull_arguments"].Description,
 			MarkdownDescription: descriptions["pull_arguments"].MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
 			ElementType:         types.StringType,
 			Validators: []validator.List{
 				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
@@ -259,11 +433,19 @@ func executionEnvironmentAttributes(target surface) map[string]schema.Attribute
 			Description:         descriptions["container_options"].Description,
 			MarkdownDescription: descriptions["container_options"].MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
 			ElementType:         types.StringType,
 			Validators: []validator.List{
 				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
 			},
 		},
+		"require_image_digest": schema.BoolAttribute{
+			Description:         descriptions["require_image_digest"].Description,
+			MarkdownDescription: descriptions["require_image_digest"].MarkdownDescription,
+			Optional:            true,
+			Computed:            target.allowsComputed(),
+			Default:             target.boolDefault(defaultNavigatorRunRequireImageDigest),
+		},
 	}
 }
 
@@ -276,7 +458,10 @@ func ansibleOptionsAttributes(target surface) map[string]schema.Attribute {
 		"limit":             describe("Further limit selected hosts to an additional pattern."),
 		"tags":              describe("Only run plays and tasks tagged with these values."),
 		"private_keys":      describe("SSH private keys used for authentication in addition to the [automatically mounted](https://ansible.readthedocs.io/projects/navigator/faq/#how-do-i-use-my-ssh-keys-with-an-execution-environment) default named keys and SSH agent socket path."),
-		"known_hosts":       describe("SSH known host entries. Ansible variable `%s` set to path of `known_hosts` file and SSH option `UserKnownHostsFile` must be configured to that path. Defaults to all of the `known_hosts` entries recorded.", ansible.SSHKnownHostsFileVar),
+		"ssh_agent":         describe("Serve all private keys from an SSH agent started for the duration of the run, instead of writing them to the run directory. Nothing is written for these keys and they are not passed with `--private-key`, the agent offers them along with their certificates, so SSH options such as `IdentitiesOnly` must not exclude agent keys. The agent takes the place of any SSH agent in the environment Terraform runs in. Keys with a passphrase are always served this way."),
+		"vault_passwords":   describe("[Vault](https://docs.ansible.com/ansible/latest/vault_guide/index.html) passwords keyed by [vault ID](https://docs.ansible.com/ansible/latest/vault_guide/vault_managing_passwords.html#managing-multiple-passwords-with-vault-ids). Each password is written to a file within the run directory and passed to Ansible with `--vault-id`. Unless `vault_id_match` is enabled in `ansible.cfg`, Ansible tries every password, so a single entry such as `default` also decrypts content encrypted without a vault ID."),
+		"known_hosts":       describe("SSH known host entries, including `@cert-authority` entries to trust host certificates signed by a certificate authority. Ansible variable `%s` set to path of `known_hosts` file and SSH option `UserKnownHostsFile` must be configured to that path. Defaults to all of the `known_hosts` entries recorded.", ansible.SSHKnownHostsFileVar),
+		"ssh_proxy":         describe("Bastion host that SSH connections are tunneled through, configured by a generated `ssh_config` file. Ansible variable `%s` is set to the path of that file, and SSH option `ProxyCommand` must be configured to use it, see the `ssh_proxy_args` function. The host key of the bastion is checked against the same `known_hosts` file as other hosts.", ansible.SSHProxyConfigFileVar),
 		"host_key_checking": describe("SSH host key checking. Can help protect against man-in-the-middle attacks by verifying the identity of hosts. Ansible runner (library used by `%s`) defaults this option to `%t` explicitly.", navigator.Program, ansible.RunnerDefaultHostKeyChecking),
 	}
 
@@ -285,6 +470,7 @@ func ansibleOptionsAttributes(target surface) map[string]schema.Attribute {
 			Description:         descriptions["extra_vars"].Description,
 			MarkdownDescription: descriptions["extra_vars"].MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
 			Validators: []validator.String{
 				stringvalidator.LengthAtLeast(1),
 				stringIsYAML(),
@@ -294,11 +480,13 @@ func ansibleOptionsAttributes(target surface) map[string]schema.Attribute {
 			Description:         descriptions["force_handlers"].Description,
 			MarkdownDescription: descriptions["force_handlers"].MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
 		},
 		"skip_tags": schema.ListAttribute{
 			Description:         descriptions["skip_tags"].Description,
 			MarkdownDescription: descriptions["skip_tags"].MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
 			ElementType:         types.StringType,
 			Validators: []validator.List{
 				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
@@ -308,6 +496,7 @@ func ansibleOptionsAttributes(target surface) map[string]schema.Attribute {
 			Description:         descriptions["start_at_task"].Description,
 			MarkdownDescription: descriptions["start_at_task"].MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
 			Validators: []validator.String{
 				stringvalidator.LengthAtLeast(1),
 			},
@@ -316,6 +505,7 @@ func ansibleOptionsAttributes(target surface) map[string]schema.Attribute {
 			Description:         descriptions["limit"].Description,
 			MarkdownDescription: descriptions["limit"].MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
 			ElementType:         types.StringType,
 			Validators: []validator.List{
 				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
@@ -325,6 +515,7 @@ func ansibleOptionsAttributes(target surface) map[string]schema.Attribute {
 			Description:         descriptions["tags"].Description,
 			MarkdownDescription: descriptions["tags"].MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
 			ElementType:         types.StringType,
 			Validators: []validator.List{
 				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
@@ -334,8 +525,31 @@ func ansibleOptionsAttributes(target surface) map[string]schema.Attribute {
 			Description:         descriptions["private_keys"].Description,
 			MarkdownDescription: descriptions["private_keys"].MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
 			NestedObject: schema.NestedAttributeObject{
 				Attributes: privateKeyAttributes(target),
+				Validators: []validator.Object{
+					objectIsSSHPrivateKeyWithPassphrase(),
+					objectIsSSHPrivateKeyWithCertificate(),
+				},
+			},
+		},
+		"ssh_agent": schema.BoolAttribute{
+			Description:         descriptions["ssh_agent"].Description,
+			MarkdownDescription: descriptions["ssh_agent"].MarkdownDescription,
+			Optional:            true,
+			Computed:            target.allowsComputed(),
+		},
+		"vault_passwords": schema.MapAttribute{
+			Description:         descriptions["vault_passwords"].Description,
+			MarkdownDescription: descriptions["vault_passwords"].MarkdownDescription,
+			Optional:            true,
+			Computed:            target.allowsComputed(),
+			Sensitive:           target.allowsSensitive(),
+			ElementType:         types.StringType,
+			Validators: []validator.Map{
+				mapvalidator.KeysAre(stringIsVaultID()),
+				mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
 			},
 		},
 		"known_hosts": schema.ListAttribute{
@@ -352,14 +566,199 @@ func ansibleOptionsAttributes(target surface) map[string]schema.Attribute {
 			Description:         descriptions["host_key_checking"].Description,
 			MarkdownDescription: descriptions["host_key_checking"].MarkdownDescription,
 			Optional:            true,
+			Computed:            target.allowsComputed(),
+		},
+		"ssh_proxy": schema.SingleNestedAttribute{
+			Description:         descriptions["ssh_proxy"].Description,
+			MarkdownDescription: descriptions["ssh_proxy"].MarkdownDescription,
+			Optional:            true,
+			Computed:            target.allowsComputed(),
+			Attributes:          sshProxyAttributes(),
+		},
+	}
+}
+
+func sshProxyAttributes() map[string]schema.Attribute {
+	descriptions := map[string]attrDescription{
+		"host":             describe("Hostname or IP address of the bastion."),
+		"port":             describe("SSH port of the bastion. Defaults to `%d`.", ansible.DefaultSSHPort),
+		"user":             describe("User to connect to the bastion as. Defaults to the SSH default."),
+		"private_key_name": describe("Name of an entry in `private_keys` to authenticate to the bastion with. Otherwise, or when the key is served by an SSH agent, SSH picks keys as usual."),
+		"known_hosts":      describe("SSH known host entries of the bastion, added to the `known_hosts` file. Not needed when the bastion is already covered by `known_hosts`."),
+	}
+
+	return map[string]schema.Attribute{
+		"host": schema.StringAttribute{
+			Description:         descriptions["host"].Description,
+			MarkdownDescription: descriptions["host"].MarkdownDescription,
+			Required:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"port": schema.Int64Attribute{
+			Description:         descriptions["port"].Description,
+			MarkdownDescription: descriptions["port"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(ansible.MinPort, ansible.MaxPort),
+			},
+		},
+		"user": schema.StringAttribute{
+			Description:         descriptions["user"].Description,
+			MarkdownDescription: descriptions["user"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"private_key_name": schema.StringAttribute{
+			Description:         descriptions["private_key_name"].Description,
+			MarkdownDescription: descriptions["private_key_name"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.String{
+				stringIsSSHPrivateKeyName(),
+			},
+		},
+		"known_hosts": schema.ListAttribute{
+			Description:         descriptions["known_hosts"].Description,
+			MarkdownDescription: descriptions["known_hosts"].MarkdownDescription,
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.ValueStringsAre(stringIsSSHKnownHost()),
+			},
+		},
+	}
+}
+
+func ansibleRunnerAttributes() map[string]schema.Attribute {
+	descriptions := map[string]attrDescription{
+		"binary": describe("Path to the `%s` binary. By default `$PATH` is searched.", ansible.RunnerProgram),
+	}
+
+	return map[string]schema.Attribute{
+		"binary": schema.StringAttribute{
+			Description:         descriptions["binary"].Description,
+			MarkdownDescription: descriptions["binary"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+	}
+}
+
+func requirementsAttributes() map[string]schema.Attribute {
+	descriptions := map[string]attrDescription{
+		"contents": describe("[Requirements file](https://docs.ansible.com/ansible/latest/galaxy/user_guide.html#install-multiple-collections-with-a-requirements-file) contents (YAML). Collections and roles are both installed."),
+		"source":   describe("Path to a collection tarball or collection source directory, relative to `working_directory`. Installed with `--offline` before `contents`, so no Galaxy server is contacted for it."),
+	}
+
+	return map[string]schema.Attribute{
+		"contents": schema.StringAttribute{
+			Description:         descriptions["contents"].Description,
+			MarkdownDescription: descriptions["contents"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringIsYAML(),
+				stringvalidator.AtLeastOneOf(path.MatchRelative().AtParent().AtName("source")),
+			},
+		},
+		"source": schema.StringAttribute{
+			Description:         descriptions["source"].Description,
+			MarkdownDescription: descriptions["source"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+	}
+}
+
+func projectFileAttributes() map[string]schema.Attribute {
+	descriptions := map[string]attrDescription{
+		"contents": describe("File contents."),
+		"mode":     describe("File mode in octal notation, such as `0755`. Must set at least one permission bit. Defaults to `0600`."),
+	}
+
+	return map[string]schema.Attribute{
+		"contents": schema.StringAttribute{
+			Description:         descriptions["contents"].Description,
+			MarkdownDescription: descriptions["contents"].MarkdownDescription,
+			Required:            true,
+		},
+		"mode": schema.StringAttribute{
+			Description:         descriptions["mode"].Description,
+			MarkdownDescription: descriptions["mode"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.String{
+				stringIsFileMode(),
+			},
+		},
+	}
+}
+
+func retryAttributes() map[string]schema.Attribute {
+	descriptions := map[string]attrDescription{
+		"max_attempts":           describe("Maximum number of attempts, including the first. Defaults to `%d`.", defaultNavigatorRunRetryMaxAttempts),
+		"initial_backoff":        describe("Duration to wait before the first retry, doubled before each subsequent retry. Defaults to `%s`.", defaultNavigatorRunRetryInitialBackoff),
+		"max_backoff":            describe("Upper bound of the duration between attempts. Defaults to `%s`.", defaultNavigatorRunRetryMaxBackoff),
+		"retry_on":               describe("Outcomes of an attempt that are retried. An attempt is `%s` when every failing host is unreachable, otherwise `%s`, including when some hosts failed and others were unreachable. Options: %s. Defaults to `%s`.", navigator.RetryOnUnreachable, navigator.RetryOnFailed, wrapElementsJoin(navigator.AllRetryOns().Strings(), "`"), defaultNavigatorRunRetryOn),
+		"unreachable_hosts_only": describe("Limit each retry to the hosts of the previous attempt whose outcome is in `retry_on`, in place of `ansible_options.limit`: unreachable hosts when `unreachable` is retried and failed hosts when `failed` is retried. Attempts without such hosts are not retried. Defaults to `false`."),
+	}
+
+	return map[string]schema.Attribute{
+		"max_attempts": schema.Int64Attribute{
+			Description:         descriptions["max_attempts"].Description,
+			MarkdownDescription: descriptions["max_attempts"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			},
+		},
+		"initial_backoff": schema.StringAttribute{
+			Description:         descriptions["initial_backoff"].Description,
+			MarkdownDescription: descriptions["initial_backoff"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.String{
+				stringIsBackoff(),
+			},
+		},
+		"max_backoff": schema.StringAttribute{
+			Description:         descriptions["max_backoff"].Description,
+			MarkdownDescription: descriptions["max_backoff"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.String{
+				stringIsBackoff(),
+			},
+		},
+		"retry_on": schema.ListAttribute{
+			Description:         descriptions["retry_on"].Description,
+			MarkdownDescription: descriptions["retry_on"].MarkdownDescription,
+			Optional:            true,
+			ElementType:         types.StringType,
+			Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.UniqueValues(),
+				listvalidator.ValueStringsAre(stringvalidator.OneOf(navigator.AllRetryOns().Strings()...)),
+			},
+		},
+		"unreachable_hosts_only": schema.BoolAttribute{
+			Description:         descriptions["unreachable_hosts_only"].Description,
+			MarkdownDescription: descriptions["unreachable_hosts_only"].MarkdownDescription,
+			Optional:            true,
 		},
 	}
 }
 
 func privateKeyAttributes(target surface) map[string]schema.Attribute {
 	descriptions := map[string]attrDescription{
-		"name": describe("Key name."),
-		"data": describe("Key data."),
+		"name":        describe("Key name."),
+		"data":        describe("Key data."),
+		"passphrase":  describe("Passphrase of the key, required if and only if the key is encrypted. The decrypted key is never written to disk, instead it is served from memory by an SSH agent for the duration of the run, as with `ssh_agent`."),
+		"certificate": describe("OpenSSH user certificate issued for the key, in authorized keys format. Written next to the key as `<name>%s` so that SSH uses it, or added to the SSH agent along with a key it serves. Checked for expiry before the playbook runs.", ansible.SSHCertificateSuffix),
 	}
 
 	return map[string]schema.Attribute{
@@ -380,6 +779,23 @@ func privateKeyAttributes(target surface) map[string]schema.Attribute {
 				stringIsSSHPrivateKey(),
 			},
 		},
+		"passphrase": schema.StringAttribute{
+			Description:         descriptions["passphrase"].Description,
+			MarkdownDescription: descriptions["passphrase"].MarkdownDescription,
+			Optional:            true,
+			Sensitive:           target.allowsSensitive(),
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"certificate": schema.StringAttribute{
+			Description:         descriptions["certificate"].Description,
+			MarkdownDescription: descriptions["certificate"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.String{
+				stringIsSSHCertificate(),
+			},
+		},
 	}
 }
 
@@ -398,7 +814,7 @@ func artifactQueryAttributes() map[string]schema.Attribute {
 				stringIsJQFilter(),
 			},
 		},
-		"results": schema.ListAttribute{ // TODO switch to a dynamic attribute when supported as an element in a collection
+		"results": schema.ListAttribute{ // decoded in artifact_query_results, dynamic attributes are not supported as an element in a collection
 			Description:         descriptions["results"].Description,
 			MarkdownDescription: descriptions["results"].MarkdownDescription,
 			Computed:            true,
@@ -410,11 +826,184 @@ func artifactQueryAttributes() map[string]schema.Attribute {
 	}
 }
 
+func hostStatsAttributes() map[string]schema.Attribute {
+	descriptions := map[string]attrDescription{
+		"ok":          describe("Tasks that succeeded, including those that reported changes."),
+		"changed":     describe("Tasks that reported changes."),
+		"unreachable": describe("Tasks that could not connect to the host."),
+		"failed":      describe("Tasks that failed."),
+		"skipped":     describe("Tasks that were skipped."),
+		"rescued":     describe("Failed tasks recovered by a `rescue` section. Only reported by the `PLAY RECAP`, the job events of the playbook artifact do not mark a failure as rescued. Without a recap, such as with a non-default stdout callback or an interrupted run, the counter is always `0` and the failure is counted as `failed`."),
+		"ignored":     describe("Failed tasks with `ignore_errors` enabled."),
+	}
+
+	return map[string]schema.Attribute{
+		"ok": schema.Int64Attribute{
+			Description:         descriptions["ok"].Description,
+			MarkdownDescription: descriptions["ok"].MarkdownDescription,
+			Computed:            true,
+		},
+		"changed": schema.Int64Attribute{
+			Description:         descriptions["changed"].Description,
+			MarkdownDescription: descriptions["changed"].MarkdownDescription,
+			Computed:            true,
+		},
+		"unreachable": schema.Int64Attribute{
+			Description:         descriptions["unreachable"].Description,
+			MarkdownDescription: descriptions["unreachable"].MarkdownDescription,
+			Computed:            true,
+		},
+		"failed": schema.Int64Attribute{
+			Description:         descriptions["failed"].Description,
+			MarkdownDescription: descriptions["failed"].MarkdownDescription,
+			Computed:            true,
+		},
+		"skipped": schema.Int64Attribute{
+			Description:         descriptions["skipped"].Description,
+			MarkdownDescription: descriptions["skipped"].MarkdownDescription,
+			Computed:            true,
+		},
+		"rescued": schema.Int64Attribute{
+			Description:         descriptions["rescued"].Description,
+			MarkdownDescription: descriptions["rescued"].MarkdownDescription,
+			Computed:            true,
+		},
+		"ignored": schema.Int64Attribute{
+			Description:         descriptions["ignored"].Description,
+			MarkdownDescription: descriptions["ignored"].MarkdownDescription,
+			Computed:            true,
+		},
+	}
+}
+
+func taskResultAttributes() map[string]schema.Attribute {
+	descriptions := map[string]attrDescription{
+		"play":    describe("Play name."),
+		"task":    describe("Task name."),
+		"action":  describe("Module or action plugin, for example `ansible.builtin.copy`."),
+		"host":    describe("Inventory hostname."),
+		"status":  describe("Outcome of the task. Options: %s. A failure recovered by a `rescue` section is `failed`, as the playbook artifact does not mark it as rescued.", wrapElementsJoin(ansible.AllTaskStatuses().Strings(), "`")),
+		"changed": describe("Whether the task reported changes."),
+	}
+
+	return map[string]schema.Attribute{
+		"play": schema.StringAttribute{
+			Description:         descriptions["play"].Description,
+			MarkdownDescription: descriptions["play"].MarkdownDescription,
+			Computed:            true,
+		},
+		"task": schema.StringAttribute{
+			Description:         descriptions["task"].Description,
+			MarkdownDescription: descriptions["task"].MarkdownDescription,
+			Computed:            true,
+		},
+		"action": schema.StringAttribute{
+			Description:         descriptions["action"].Description,
+			MarkdownDescription: descriptions["action"].MarkdownDescription,
+			Computed:            true,
+		},
+		"host": schema.StringAttribute{
+			Description:         descriptions["host"].Description,
+			MarkdownDescription: descriptions["host"].MarkdownDescription,
+			Computed:            true,
+		},
+		"status": schema.StringAttribute{
+			Description:         descriptions["status"].Description,
+			MarkdownDescription: descriptions["status"].MarkdownDescription,
+			Computed:            true,
+		},
+		"changed": schema.BoolAttribute{
+			Description:         descriptions["changed"].Description,
+			MarkdownDescription: descriptions["changed"].MarkdownDescription,
+			Computed:            true,
+		},
+	}
+}
+
+func timingAttributes() map[string]schema.Attribute {
+	descriptions := map[string]attrDescription{
+		"wall_time_seconds": describe("Seconds from the first task to start to the last to finish."),
+		"slowest_tasks":     describe("Up to `timing_slowest_tasks` tasks that took the longest, slowest first. A task spans every host it ran on."),
+		"play":              describe("Play name."),
+		"task":              describe("Task name."),
+		"duration_seconds":  describe("Seconds from the first host to start the task to the last to finish it."),
+	}
+
+	return map[string]schema.Attribute{
+		"wall_time_seconds": schema.Float64Attribute{
+			Description:         descriptions["wall_time_seconds"].Description,
+			MarkdownDescription: descriptions["wall_time_seconds"].MarkdownDescription,
+			Computed:            true,
+		},
+		"slowest_tasks": schema.ListNestedAttribute{
+			Description:         descriptions["slowest_tasks"].Description,
+			MarkdownDescription: descriptions["slowest_tasks"].MarkdownDescription,
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"play": schema.StringAttribute{
+						Description:         descriptions["play"].Description,
+						MarkdownDescription: descriptions["play"].MarkdownDescription,
+						Computed:            true,
+					},
+					"task": schema.StringAttribute{
+						Description:         descriptions["task"].Description,
+						MarkdownDescription: descriptions["task"].MarkdownDescription,
+						Computed:            true,
+					},
+					"duration_seconds": schema.Float64Attribute{
+						Description:         descriptions["duration_seconds"].Description,
+						MarkdownDescription: descriptions["duration_seconds"].MarkdownDescription,
+						Computed:            true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func artifactExportAttributes() map[string]schema.Attribute {
+	descriptions := map[string]attrDescription{
+		"directory": describe("Directory the files are exported to, created when missing. Files are named `<id>-<run>-<operation>.<file>`, after the resource `id`, the run number and the operation (`create`, `update` or `delete`), so that exports of different operations never replace each other."),
+		"gzip":      describe("Compress the exported files with gzip. Defaults to `false`."),
+		"retain":    describe("Number of runs kept per resource, the files of older runs are removed. Use `0` to keep every run. Defaults to `%d`.", defaultNavigatorRunArtifactExportRetain),
+	}
+
+	return map[string]schema.Attribute{
+		"directory": schema.StringAttribute{
+			Description:         descriptions["directory"].Description,
+			MarkdownDescription: descriptions["directory"].MarkdownDescription,
+			Required:            true,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"gzip": schema.BoolAttribute{
+			Description:         descriptions["gzip"].Description,
+			MarkdownDescription: descriptions["gzip"].MarkdownDescription,
+			Optional:            true,
+		},
+		"retain": schema.Int64Attribute{
+			Description:         descriptions["retain"].Description,
+			MarkdownDescription: descriptions["retain"].MarkdownDescription,
+			Optional:            true,
+			Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			},
+		},
+	}
+}
+
 func navigatorRunResourceAttributes() map[string]schema.Attribute {
 	descriptions := map[string]attrDescription{
-		"run_on_destroy":   describe("Run playbook (or alternatively `destroy_playbook` if configured) on destroy. The environment variable `%s` is set to `%s` during the run to allow for conditional plays, tasks, etc. Defaults to `%t`.", navigatorRunOperationEnvVar, terraformOpDelete, defaultNavigatorRunOnDestroy),
-		"destroy_playbook": playbookDescription().append("Only run on destroy (`run_on_destroy` must be `true`)."),
-		"triggers":         describe("Trigger various behaviors via arbitrary values."),
+		"run_on_destroy":       describe("Run playbook (or alternatively `destroy_playbook` if configured) on destroy. The environment variable `%s` is set to `%s` during the run to allow for conditional plays, tasks, etc. Defaults to `%t`.", navigatorRunOperationEnvVar, terraformOpDelete, defaultNavigatorRunOnDestroy),
+		"destroy_playbook":     playbookDescription().append("Only run on destroy (`run_on_destroy` must be `true`)."),
+		"triggers":             describe("Trigger various behaviors via arbitrary values."),
+		"plan_check_mode":      describe("Preview the playbook during plan by running it in [check mode](https://docs.ansible.com/ansible/latest/playbook_guide/playbooks_checkmode.html) with `--check --diff` whenever a run is planned. Only possible when the configuration is fully known. Predicted changed tasks and hosts are reported as plan warnings only, they are not recorded in state. The environment variable `%s` is set to `%s` during the preview. Terraform plans again while applying, so the preview runs a second time and its warnings are repeated. Defaults to `%t`.", navigatorRunOperationEnvVar, terraformOpPlan, defaultNavigatorRunPlanCheckMode),
+		"drift_detection":      describe("Detect configuration drift on refresh by running the playbook from state in check mode with `--check --diff`. When any task reports changes, `drift_detected` is set, the changed tasks are reported as a warning, and the next plan runs the playbook again (unless `triggers.exclusive_run` is set). The environment variable `%s` is set to `%s` during the check. Failed checks are reported as warnings. Defaults to `%t`.", navigatorRunOperationEnvVar, terraformOpRead, defaultNavigatorRunDriftDetection),
+		"drift_detected":       describe("Whether the most recent `drift_detection` check found tasks that would change. Reset by the next run."),
+		"artifact_export":      describe("Export the playbook artifact and `%s` log of each run on create, update and destroy, overriding the provider `artifact_export` setting. Sensitive values are masked in both files.", navigator.Program),
+		"artifact_export_path": describe("Path of the playbook artifact exported by the most recent run. Null when runs are not exported."),
 	}
 
 	triggers := map[string]attrDescription{
@@ -441,6 +1030,39 @@ func navigatorRunResourceAttributes() map[string]schema.Attribute {
 				stringIsYAML(),
 			},
 		},
+		"plan_check_mode": schema.BoolAttribute{
+			Description:         descriptions["plan_check_mode"].Description,
+			MarkdownDescription: descriptions["plan_check_mode"].MarkdownDescription,
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(defaultNavigatorRunPlanCheckMode),
+		},
+		"drift_detection": schema.BoolAttribute{
+			Description:         descriptions["drift_detection"].Description,
+			MarkdownDescription: descriptions["drift_detection"].MarkdownDescription,
+			Optional:            true,
+			Computed:            true,
+			Default:             booldefault.StaticBool(defaultNavigatorRunDriftDetection),
+		},
+		"drift_detected": schema.BoolAttribute{
+			Description:         descriptions["drift_detected"].Description,
+			MarkdownDescription: descriptions["drift_detected"].MarkdownDescription,
+			Computed:            true,
+		},
+		"artifact_export": schema.SingleNestedAttribute{
+			Description:         descriptions["artifact_export"].Description,
+			MarkdownDescription: descriptions["artifact_export"].MarkdownDescription,
+			Optional:            true,
+			Attributes:          artifactExportAttributes(),
+		},
+		"artifact_export_path": schema.StringAttribute{
+			Description:         descriptions["artifact_export_path"].Description,
+			MarkdownDescription: descriptions["artifact_export_path"].MarkdownDescription,
+			Computed:            true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
 		"triggers": schema.SingleNestedAttribute{
 			Description:         descriptions["triggers"].Description,
 			MarkdownDescription: descriptions["triggers"].MarkdownDescription,
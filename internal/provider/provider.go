@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -16,6 +17,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
 	"github.com/spf13/afero"
 )
@@ -36,6 +38,9 @@ type AnsibleProvider struct {
 type AnsibleProviderModel struct {
 	BaseRunDirectory    types.String `tfsdk:"base_run_directory"`
 	PersistRunDirectory types.Bool   `tfsdk:"persist_run_directory"`
+	MaxConcurrentRuns   types.Int64  `tfsdk:"max_concurrent_runs"`
+	Defaults            types.Object `tfsdk:"defaults"`
+	ArtifactExport      types.Object `tfsdk:"artifact_export"`
 }
 
 func (p *AnsibleProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -61,6 +66,67 @@ func (p *AnsibleProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				MarkdownDescription: fmt.Sprintf("Remove run directory after the run completes. Useful when troubleshooting. Defaults to `%t`.", defaultProviderPersistRunDir),
 				Optional:            true,
 			},
+			"max_concurrent_runs": schema.Int64Attribute{
+				Description:         "Maximum number of playbook runs in progress at once, shared by every resource, data source, ephemeral resource and action of the provider. Further runs are queued, and time spent queued counts against their timeout. By default runs are not limited.",
+				MarkdownDescription: "Maximum number of playbook runs in progress at once, shared by every resource, data source, ephemeral resource and action of the provider. Further runs are queued, and time spent queued counts against their timeout. By default runs are not limited.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"defaults": schema.SingleNestedAttribute{
+				Description:         providerDefaultsDescription().Description,
+				MarkdownDescription: providerDefaultsDescription().MarkdownDescription,
+				Optional:            true,
+				Attributes:          providerDefaultsAttributes(),
+			},
+			"artifact_export": schema.SingleNestedAttribute{
+				Description:         "Export the playbook artifact and 'ansible-navigator' log of each 'ansible_navigator_run' resource run on create, update and destroy. Unlike 'persist_run_directory', only these files are kept, without the private keys and other contents of the run directory. Sensitive values are masked in both files, as in state and logs. The resource 'artifact_export' attribute takes precedence. The path of the most recent export is recorded in the resource 'artifact_export_path' attribute.",
+				MarkdownDescription: "Export the playbook artifact and `ansible-navigator` log of each `ansible_navigator_run` resource run on create, update and destroy. Unlike `persist_run_directory`, only these files are kept, without the private keys and other contents of the run directory. Sensitive values are masked in both files, as in state and logs. The resource `artifact_export` attribute takes precedence. The path of the most recent export is recorded in the resource `artifact_export_path` attribute.",
+				Optional:            true,
+				Attributes:          providerAttributes(artifactExportAttributes()),
+			},
+		},
+	}
+}
+
+func providerDefaultsDescription() attrDescription {
+	return describe("Defaults for every `ansible_navigator_run` resource, data source, ephemeral resource and action. Merged attribute by attribute, values set on the resource take precedence. Changing a default runs the playbook of affected resources again.")
+}
+
+func providerDefaultsAttributes() map[string]schema.Attribute {
+	optsAttributes := ansibleOptionsAttributes(surfaceResource)
+	// recorded per resource
+	delete(optsAttributes, "known_hosts")
+
+	return map[string]schema.Attribute{
+		"execution_environment": schema.SingleNestedAttribute{
+			Description:         "Defaults for 'execution_environment'.",
+			MarkdownDescription: "Defaults for `execution_environment`.",
+			Optional:            true,
+			Attributes:          providerAttributes(executionEnvironmentAttributes(surfaceResource)),
+		},
+		"ansible_options": schema.SingleNestedAttribute{
+			Description:         "Defaults for 'ansible_options'.",
+			MarkdownDescription: "Defaults for `ansible_options`.",
+			Optional:            true,
+			Attributes:          providerAttributes(optsAttributes),
+		},
+		"timezone": schema.StringAttribute{
+			Description:         "Default for 'timezone'.",
+			MarkdownDescription: "Default for `timezone`.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringIsIANATimezone(),
+			},
+		},
+		"cancel_grace_period": schema.StringAttribute{
+			Description:         "Default for 'cancel_grace_period'.",
+			MarkdownDescription: "Default for `cancel_grace_period`.",
+			Optional:            true,
+			Validators: []validator.String{
+				stringIsCancelGracePeriod(),
+			},
 		},
 	}
 }
@@ -86,6 +152,24 @@ func (p *AnsibleProvider) Configure(ctx context.Context, req provider.ConfigureR
 		resp.Diagnostics.AddAttributeError(path, summary, detail)
 	}
 
+	if !data.Defaults.IsNull() && !configValueIsKnown(ctx, data.Defaults) {
+		path := path.Root("defaults")
+		summary, detail := unknownProviderValue(path)
+		resp.Diagnostics.AddAttributeError(path, summary, detail)
+	}
+
+	if !data.ArtifactExport.IsNull() && !configValueIsKnown(ctx, data.ArtifactExport) {
+		path := path.Root("artifact_export")
+		summary, detail := unknownProviderValue(path)
+		resp.Diagnostics.AddAttributeError(path, summary, detail)
+	}
+
+	if data.MaxConcurrentRuns.IsUnknown() {
+		path := path.Root("max_concurrent_runs")
+		summary, detail := unknownProviderValue(path)
+		resp.Diagnostics.AddAttributeError(path, summary, detail)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -93,6 +177,7 @@ func (p *AnsibleProvider) Configure(ctx context.Context, req provider.ConfigureR
 	opts := providerOptions{
 		BaseRunDirectory:    os.TempDir(),
 		PersistRunDirectory: defaultProviderPersistRunDir,
+		ArtifactExport:      data.ArtifactExport,
 	}
 
 	if !data.BaseRunDirectory.IsNull() {
@@ -106,6 +191,12 @@ func (p *AnsibleProvider) Configure(ctx context.Context, req provider.ConfigureR
 		opts.PersistRunDirectory = data.PersistRunDirectory.ValueBool()
 	}
 
+	opts.RunLimiter = newRunLimiter(data.MaxConcurrentRuns.ValueInt64())
+
+	if !data.Defaults.IsNull() {
+		resp.Diagnostics.Append(data.Defaults.As(ctx, &opts.Defaults, basetypes.ObjectAsOptions{})...)
+	}
+
 	resp.ResourceData = &opts
 	resp.DataSourceData = &opts
 	resp.EphemeralResourceData = &opts
@@ -121,6 +212,7 @@ func (p *AnsibleProvider) Resources(_ context.Context) []func() resource.Resourc
 func (p *AnsibleProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewNavigatorRunDataSource,
+		NewSSHHostKeysDataSource,
 	}
 }
 
@@ -139,7 +231,9 @@ func (p *AnsibleProvider) Actions(_ context.Context) []func() action.Action {
 func (p *AnsibleProvider) Functions(_ context.Context) []func() function.Function {
 	return []func() function.Function{
 		NewSSHArgsFunction,
+		NewSSHProxyArgsFunction,
 		NewSSHKnownHostFunction,
+		NewInventoryFunction,
 	}
 }
 
@@ -7,8 +7,13 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/config"
 	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 	"github.com/marshallford/terraform-provider-ansible/internal/provider"
 )
 
@@ -32,6 +37,10 @@ func TestAccProvider_errors(t *testing.T) {
 			name:     "unknown_persist_run_directory",
 			expected: regexp.MustCompile("Unknown configuration value 'persist_run_directory'"),
 		},
+		{
+			name:     "unknown_max_concurrent_runs",
+			expected: regexp.MustCompile("Unknown configuration value 'max_concurrent_runs'"),
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -49,3 +58,76 @@ func TestAccProvider_errors(t *testing.T) {
 		})
 	}
 }
+
+func TestAccProvider_max_concurrent_runs(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:          testTerraformFiles(t, filepath.Join("provider", "max_concurrent_runs")),
+				ConfigVariables: testDefaultConfigVariables(t),
+			},
+		},
+	})
+}
+
+func TestAccProvider_artifact_export(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testTerraformFiles(t, filepath.Join("provider", "artifact_export")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"export_directory": config.StringVariable(t.TempDir()),
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ansible_navigator_run.test", tfjsonpath.New("artifact_export_path"), knownvalue.StringRegexp(regexp.MustCompile(`-1-create\.playbook-artifact\.json$`))),
+				},
+			},
+		},
+	})
+}
+
+func TestAccProvider_defaults(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:          testTerraformFiles(t, filepath.Join("provider", "defaults")),
+				ConfigVariables: testDefaultConfigVariables(t),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ansible_navigator_run.test", tfjsonpath.New("timezone"), knownvalue.StringExact("America/New_York")),
+					statecheck.ExpectKnownValue("ansible_navigator_run.test", tfjsonpath.New("execution_environment").AtMapKey("pull_policy"), knownvalue.StringExact("tag")),
+					statecheck.ExpectKnownValue("ansible_navigator_run.test", tfjsonpath.New("execution_environment").AtMapKey("environment_variables_set"), knownvalue.MapExact(map[string]knownvalue.Check{"FROM_DEFAULTS": knownvalue.StringExact("true")})),
+					statecheck.ExpectKnownValue("ansible_navigator_run.test", tfjsonpath.New("ansible_options").AtMapKey("tags"), knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("defaults")})),
+				},
+			},
+			{
+				Config: testTerraformFiles(t, filepath.Join("provider", "defaults")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"default_timezone": config.StringVariable("Europe/Paris"),
+					"ansible_options":  config.ObjectVariable(map[string]config.Variable{"tags": config.ListVariable(config.StringVariable("resource"))}),
+				}),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ansible_navigator_run.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("ansible_navigator_run.test", tfjsonpath.New("timezone"), knownvalue.StringExact("Europe/Paris")),
+					statecheck.ExpectKnownValue("ansible_navigator_run.test", tfjsonpath.New("execution_environment").AtMapKey("environment_variables_set"), knownvalue.MapExact(map[string]knownvalue.Check{"FROM_DEFAULTS": knownvalue.StringExact("true")})),
+					statecheck.ExpectKnownValue("ansible_navigator_run.test", tfjsonpath.New("ansible_options").AtMapKey("tags"), knownvalue.ListExact([]knownvalue.Check{knownvalue.StringExact("resource")})),
+				},
+			},
+		},
+	})
+}
@@ -13,12 +13,14 @@ import (
 	resourceTimeouts "github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/action"
 	aschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	eschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	pschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -26,6 +28,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
@@ -62,6 +65,50 @@ func (d attrDescription) append(markdown string, args ...any) attrDescription {
 type providerOptions struct {
 	BaseRunDirectory    string
 	PersistRunDirectory bool
+	RunLimiter          runLimiter
+	Defaults            NavigatorRunDefaultsModel
+	ArtifactExport      types.Object
+}
+
+// runLimiter is a counting semaphore shared by every run of the provider. A nil
+// limiter does not limit runs.
+type runLimiter chan struct{}
+
+func newRunLimiter(limit int64) runLimiter {
+	if limit <= 0 {
+		return nil
+	}
+
+	return make(runLimiter, limit)
+}
+
+// acquire blocks until a run slot is free or ctx is done, logging when the run
+// has to wait.
+func (l runLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+
+	tflog.Info(ctx, "run queued, waiting for a run slot", map[string]any{"maxConcurrentRuns": cap(l)})
+
+	select {
+	case l <- struct{}{}:
+		tflog.Info(ctx, "run dequeued")
+
+		return l.release, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("run queued behind %d concurrent runs, %w", cap(l), ctx.Err())
+	}
+}
+
+func (l runLimiter) release() {
+	<-l
 }
 
 type (
@@ -76,6 +123,7 @@ const (
 	terraformOpDelete terraformOp = "delete"
 	terraformOpOpen   terraformOp = "open"
 	terraformOpInvoke terraformOp = "invoke"
+	terraformOpPlan   terraformOp = "plan"
 )
 
 func (op terraformOp) String() string {
@@ -159,6 +207,90 @@ func actionAttributes(attributes map[string]schema.Attribute) map[string]aschema
 	return converted
 }
 
+// providerAttributes converts resource attributes for use in the provider
+// schema. Provider attributes are never computed, so defaults are dropped.
+func providerAttributes(attributes map[string]schema.Attribute) map[string]pschema.Attribute {
+	converted := make(map[string]pschema.Attribute, len(attributes))
+	for name, attribute := range attributes {
+		switch typed := attribute.(type) {
+		case schema.StringAttribute:
+			converted[name] = pschema.StringAttribute{
+				Description:         typed.Description,
+				MarkdownDescription: typed.MarkdownDescription,
+				Required:            typed.Required,
+				Optional:            !typed.Required,
+				Sensitive:           typed.Sensitive,
+				Validators:          typed.Validators,
+			}
+		case schema.BoolAttribute:
+			converted[name] = pschema.BoolAttribute{
+				Description:         typed.Description,
+				MarkdownDescription: typed.MarkdownDescription,
+				Required:            typed.Required,
+				Optional:            !typed.Required,
+				Sensitive:           typed.Sensitive,
+				Validators:          typed.Validators,
+			}
+		case schema.Int64Attribute:
+			converted[name] = pschema.Int64Attribute{
+				Description:         typed.Description,
+				MarkdownDescription: typed.MarkdownDescription,
+				Required:            typed.Required,
+				Optional:            !typed.Required,
+				Sensitive:           typed.Sensitive,
+				Validators:          typed.Validators,
+			}
+		case schema.ListAttribute:
+			converted[name] = pschema.ListAttribute{
+				Description:         typed.Description,
+				MarkdownDescription: typed.MarkdownDescription,
+				Required:            typed.Required,
+				Optional:            !typed.Required,
+				Sensitive:           typed.Sensitive,
+				ElementType:         typed.ElementType,
+				Validators:          typed.Validators,
+			}
+		case schema.MapAttribute:
+			converted[name] = pschema.MapAttribute{
+				Description:         typed.Description,
+				MarkdownDescription: typed.MarkdownDescription,
+				Required:            typed.Required,
+				Optional:            !typed.Required,
+				Sensitive:           typed.Sensitive,
+				ElementType:         typed.ElementType,
+				Validators:          typed.Validators,
+			}
+		case schema.ListNestedAttribute:
+			converted[name] = pschema.ListNestedAttribute{
+				Description:         typed.Description,
+				MarkdownDescription: typed.MarkdownDescription,
+				Required:            typed.Required,
+				Optional:            !typed.Required,
+				Sensitive:           typed.Sensitive,
+				NestedObject: pschema.NestedAttributeObject{
+					Attributes: providerAttributes(typed.NestedObject.Attributes),
+					Validators: typed.NestedObject.Validators,
+				},
+				Validators: typed.Validators,
+			}
+		case schema.SingleNestedAttribute:
+			converted[name] = pschema.SingleNestedAttribute{
+				Description:         typed.Description,
+				MarkdownDescription: typed.MarkdownDescription,
+				Required:            typed.Required,
+				Optional:            !typed.Required,
+				Sensitive:           typed.Sensitive,
+				Attributes:          providerAttributes(typed.Attributes),
+				Validators:          typed.Validators,
+			}
+		default:
+			panic(fmt.Sprintf("attribute '%s' of type %T not supported in the provider schema", name, attribute))
+		}
+	}
+
+	return converted
+}
+
 func terraformOperationResourceTimeout(ctx context.Context, op terraformOp, value resourceTimeouts.Value, defaultTimeout time.Duration) (time.Duration, diag.Diagnostics) {
 	switch op {
 	case terraformOpCreate:
@@ -169,7 +301,7 @@ func terraformOperationResourceTimeout(ctx context.Context, op terraformOp, valu
 		return value.Update(ctx, defaultTimeout)
 	case terraformOpDelete:
 		return value.Delete(ctx, defaultTimeout)
-	case terraformOpOpen, terraformOpInvoke:
+	case terraformOpOpen, terraformOpInvoke, terraformOpPlan:
 		return defaultTimeout, nil
 	}
 
@@ -194,6 +326,12 @@ func unknownProviderValue(value path.Path) (string, string) {
 			"Either target apply the source of the value first or set the value statically in the configuration."
 }
 
+func configValueIsKnown(ctx context.Context, value attr.Value) bool {
+	tfValue, err := value.ToTerraformValue(ctx)
+
+	return err == nil && tfValue.IsFullyKnown()
+}
+
 func unexpectedConfigureType(value string, providerData any) (string, string) {
 	return fmt.Sprintf("Unexpected %s Configure Type", value),
 		fmt.Sprintf("Expected *providerOptions, got: %T. Please report this issue to the provider developers.", providerData)
@@ -5,9 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
+	"unicode"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -18,22 +23,35 @@ import (
 )
 
 const (
-	navigatorRunName                   = "terraform"
-	navigatorRunExtraVarsFileName      = "terraform.yaml"
-	navigatorRunPrevInventoryName      = "previous-terraform"
-	navigatorRunDir                    = "tf-ansible-navigator-run"
-	navigatorRunOperationEnvVar        = "ANSIBLE_TF_OPERATION"
-	navigatorRunInventoryEnvVar        = "ANSIBLE_TF_INVENTORY"
-	navigatorRunPrevInventoryEnvVar    = "ANSIBLE_TF_PREVIOUS_INVENTORY"
-	navigatorRunTimeoutOverhead        = 5 * time.Second
-	defaultNavigatorRunWorkingDir      = "."
-	defaultNavigatorRunTimeout         = 10 * time.Minute
-	defaultNavigatorRunContainerEngine = string(navigator.ContainerEngineAuto)
-	defaultNavigatorRunEEEnabled       = true
-	defaultNavigatorRunImage           = "ghcr.io/ansible/community-ansible-dev-tools:v26.7.1"
-	defaultNavigatorRunPullPolicy      = string(navigator.PullPolicyTag)
-	defaultNavigatorRunTimezone        = "UTC"
-	defaultNavigatorRunOnDestroy       = false
+	navigatorRunName                      = "terraform"
+	navigatorRunExtraVarsFileName         = "terraform.yaml"
+	navigatorRunPrevInventoryName         = "previous-terraform"
+	navigatorRunDir                       = "tf-ansible-navigator-run"
+	navigatorRunOperationEnvVar           = "ANSIBLE_TF_OPERATION"
+	navigatorRunInventoryEnvVar           = "ANSIBLE_TF_INVENTORY"
+	navigatorRunPrevInventoryEnvVar       = "ANSIBLE_TF_PREVIOUS_INVENTORY"
+	navigatorRunTimeoutOverhead           = 5 * time.Second
+	defaultNavigatorRunWorkingDir         = "."
+	defaultNavigatorRunTimeout            = 10 * time.Minute
+	defaultNavigatorRunContainerEngine    = string(navigator.ContainerEngineAuto)
+	defaultNavigatorRunEEEnabled          = true
+	defaultNavigatorRunImage              = "ghcr.io/ansible/community-ansible-dev-tools:v26.7.1"
+	defaultNavigatorRunPullPolicy         = string(navigator.PullPolicyTag)
+	defaultNavigatorRunRequireImageDigest = false
+	defaultNavigatorRunTimezone           = "UTC"
+	defaultNavigatorRunCancelGracePeriod  = 30 * time.Second
+	defaultNavigatorRunOnDestroy          = false
+	defaultNavigatorRunPlanCheckMode      = false
+	defaultNavigatorRunDriftDetection     = false
+
+	defaultNavigatorRunRetryMaxAttempts    = 3
+	defaultNavigatorRunRetryInitialBackoff = 5 * time.Second
+	defaultNavigatorRunRetryMaxBackoff     = time.Minute
+	defaultNavigatorRunRetryOn             = navigator.RetryOnUnreachable
+
+	defaultNavigatorRunArtifactExportRetain = 5
+
+	defaultNavigatorRunTimingSlowestTasks = 10
 )
 
 type (
@@ -74,15 +92,55 @@ func incrementRuns(ctx context.Context, diags *diag.Diagnostics, getKey getKey,
 	return runs
 }
 
+// setRefreshedImageDigest keeps the digest the image resolved to when last
+// refreshed. An empty digest removes it.
+func setRefreshedImageDigest(ctx context.Context, diags *diag.Diagnostics, setKey setKey, digest string) {
+	var digestBytes []byte
+
+	if digest != "" {
+		var err error
+
+		digestBytes, err = json.Marshal(digest)
+		if addError(diags, "Failed to set 'refreshed_image_digest' private state", err) {
+			return
+		}
+	}
+
+	setKey(ctx, "refreshed_image_digest", digestBytes)
+}
+
+func getRefreshedImageDigest(ctx context.Context, diags *diag.Diagnostics, getKey getKey) string {
+	digestBytes, newDiags := getKey(ctx, "refreshed_image_digest")
+	diags.Append(newDiags...)
+
+	var digest string
+	if digestBytes != nil {
+		err := json.Unmarshal(digestBytes, &digest)
+		addError(diags, "Failed to get 'refreshed_image_digest' private state", err)
+	}
+
+	return digest
+}
+
 type navigatorRunData struct {
 	hostDir                 string
 	config                  navigator.RunConfig
 	operation               terraformOp
 	persistDir              bool
+	limiter                 runLimiter
+	export                  *navigator.ArtifactExport
+	exportPath              string
+	junitReportPath         string
 	playbookArtifactQueries map[string]ansible.PlaybookArtifactQuery
 	userArtifactQueries     bool
+	inventoryEnvVars        map[string]string
 	knownHosts              []ansible.KnownHost
 	command                 string
+	hostStats               map[string]ansible.HostStats
+	taskResults             []ansible.TaskResult
+	timeline                ansible.Timeline
+	timingSlowestTasks      int
+	imageDigest             string
 }
 
 func (rd *navigatorRunData) Load(ctx context.Context, common NavigatorRunCommonModel) diag.Diagnostics {
@@ -90,9 +148,53 @@ func (rd *navigatorRunData) Load(ctx context.Context, common NavigatorRunCommonM
 
 	rd.config.WorkingDir = common.WorkingDirectory.ValueString()
 	rd.config.Binary = common.AnsibleNavigatorBinary.ValueString()
+
+	if !common.AnsibleRunner.IsNull() {
+		var runnerModel AnsibleRunnerModel
+		diags.Append(common.AnsibleRunner.As(ctx, &runnerModel, basetypes.ObjectAsOptions{})...)
+
+		rd.config.UseRunner = true
+		rd.config.RunnerBinary = runnerModel.Binary.ValueString()
+	}
+
 	rd.config.Playbook = common.Playbook.ValueString()
-	rd.config.Inventories = []ansible.Inventory{{Name: navigatorRunName, Contents: common.Inventory.ValueString()}}
+	rd.inventoryEnvVars = map[string]string{}
+
+	if !common.Inventory.IsNull() {
+		rd.config.Inventories = append(rd.config.Inventories, ansible.Inventory{Name: navigatorRunName, Contents: common.Inventory.ValueString()})
+		rd.inventoryEnvVars[navigatorRunInventoryEnvVar] = navigatorRunName
+	}
+
+	inventories := map[string]string{}
+	if !common.Inventories.IsNull() {
+		diags.Append(common.Inventories.ElementsAs(ctx, &inventories, false)...)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(inventories)) {
+		envVar := inventoryEnvVar(name)
+		if other, ok := rd.inventoryEnvVars[envVar]; ok {
+			addPathError(&diags, path.Root("inventories").AtMapKey(name), "Inventory name conflict", fmt.Errorf("inventories '%s' and '%s' both map to environment variable '%s'", other, name, envVar))
+
+			continue
+		}
+
+		rd.config.Inventories = append(rd.config.Inventories, ansible.Inventory{Name: name, Contents: inventories[name]})
+		rd.inventoryEnvVars[envVar] = name
+	}
+
 	rd.config.Settings.Timezone = common.Timezone.ValueString()
+	rd.config.CancelGracePeriod, _ = time.ParseDuration(common.CancelGracePeriod.ValueString())
+
+	rd.junitReportPath = common.JUnitReportPath.ValueString()
+
+	rd.timingSlowestTasks = defaultNavigatorRunTimingSlowestTasks
+	if !common.TimingSlowestTasks.IsNull() {
+		rd.timingSlowestTasks = int(common.TimingSlowestTasks.ValueInt64())
+	}
+
+	if !common.RedactValues.IsNull() {
+		diags.Append(common.RedactValues.ElementsAs(ctx, &rd.config.RedactValues, false)...)
+	}
 
 	var eeModel ExecutionEnvironmentModel
 	diags.Append(common.ExecutionEnvironment.As(ctx, &eeModel, basetypes.ObjectAsOptions{})...)
@@ -108,6 +210,32 @@ func (rd *navigatorRunData) Load(ctx context.Context, common NavigatorRunCommonM
 		rd.config.ExtraVars = []ansible.ExtraVarsFile{{Name: navigatorRunExtraVarsFileName, Contents: optsModel.ExtraVars.ValueString()}}
 	}
 
+	if !common.Requirements.IsNull() {
+		var requirementsModel RequirementsModel
+		diags.Append(common.Requirements.As(ctx, &requirementsModel, basetypes.ObjectAsOptions{})...)
+
+		diags.Append(requirementsModel.Value(ctx, &rd.config.Requirements)...)
+	}
+
+	if !common.Retry.IsNull() {
+		var retryModel RetryModel
+		diags.Append(common.Retry.As(ctx, &retryModel, basetypes.ObjectAsOptions{})...)
+
+		diags.Append(retryModel.Value(ctx, &rd.config.Retry)...)
+	}
+
+	filesModel := map[string]ProjectFileModel{}
+	if !common.Files.IsNull() {
+		diags.Append(common.Files.ElementsAs(ctx, &filesModel, false)...)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(filesModel)) {
+		var file ansible.ProjectFile
+
+		diags.Append(filesModel[name].Value(ctx, name, &file)...)
+		rd.config.Files = append(rd.config.Files, file)
+	}
+
 	var privateKeysModel []PrivateKeyModel
 	if !optsModel.PrivateKeys.IsNull() {
 		diags.Append(optsModel.PrivateKeys.ElementsAs(ctx, &privateKeysModel, false)...)
@@ -121,6 +249,18 @@ func (rd *navigatorRunData) Load(ctx context.Context, common NavigatorRunCommonM
 		rd.config.PrivateKeys = append(rd.config.PrivateKeys, key)
 	}
 
+	rd.config.UseSSHAgent = optsModel.SSHAgent.ValueBool()
+
+	vaultPasswords := map[string]string{}
+	if !optsModel.VaultPasswords.IsNull() {
+		diags.Append(optsModel.VaultPasswords.ElementsAs(ctx, &vaultPasswords, false)...)
+	}
+
+	rd.config.VaultPasswords = make([]ansible.VaultPassword, 0, len(vaultPasswords))
+	for _, id := range slices.Sorted(maps.Keys(vaultPasswords)) {
+		rd.config.VaultPasswords = append(rd.config.VaultPasswords, ansible.VaultPassword{ID: id, Password: vaultPasswords[id]})
+	}
+
 	var knownHosts []string
 	if !optsModel.KnownHosts.IsUnknown() {
 		diags.Append(optsModel.KnownHosts.ElementsAs(ctx, &knownHosts, false)...)
@@ -135,14 +275,26 @@ func (rd *navigatorRunData) Load(ctx context.Context, common NavigatorRunCommonM
 		rd.config.HostKeyChecking = ansible.RunnerDefaultHostKeyChecking
 	}
 
+	if !optsModel.SSHProxy.IsNull() {
+		var proxyModel SSHProxyModel
+		diags.Append(optsModel.SSHProxy.As(ctx, &proxyModel, basetypes.ObjectAsOptions{})...)
+
+		diags.Append(proxyModel.Value(ctx, &rd.config.SSHProxy)...)
+	}
+
 	return diags
 }
 
-func (rd navigatorRunData) Store(ctx context.Context, command *types.String, ansibleOpts *types.Object, artifactQueries *types.Map) diag.Diagnostics {
+func (rd navigatorRunData) Store(ctx context.Context, command *types.String, ansibleOpts *types.Object, artifactQueries *types.Map, artifactQueryResults *types.Dynamic, hostStats *types.Map, taskResults *types.List, timing *types.Object, imageDigest *types.String) diag.Diagnostics {
 	var diags diag.Diagnostics
 
 	*command = types.StringValue(rd.command)
 
+	*imageDigest = types.StringNull()
+	if rd.imageDigest != "" {
+		*imageDigest = types.StringValue(rd.imageDigest)
+	}
+
 	var optsModel AnsibleOptionsModel
 	diags.Append(ansibleOpts.As(ctx, &optsModel, basetypes.ObjectAsOptions{})...)
 	diags.Append(optsModel.Set(ctx, rd)...)
@@ -163,9 +315,67 @@ func (rd navigatorRunData) Store(ctx context.Context, command *types.String, ans
 	diags.Append(newDiags...)
 	*artifactQueries = queriesValue
 
+	resultTypes := make(map[string]attr.Type, len(queriesModel))
+	resultValues := make(map[string]attr.Value, len(queriesModel))
+
+	for name := range queriesModel {
+		result, err := artifactQueryResult(rd.playbookArtifactQueries[name].Values)
+		if addPathError(&diags, rd.artifactQueryPath(name), "Failed to convert artifact query result", err) {
+			continue
+		}
+
+		resultTypes[name] = result.Type(ctx)
+		resultValues[name] = result
+	}
+
+	resultsValue, newDiags := types.ObjectValue(resultTypes, resultValues)
+	diags.Append(newDiags...)
+	*artifactQueryResults = types.DynamicValue(resultsValue)
+
+	hostStatsModel := make(map[string]HostStatsModel, len(rd.hostStats))
+	for host, stats := range rd.hostStats {
+		var model HostStatsModel
+
+		diags.Append(model.Set(ctx, stats)...)
+		hostStatsModel[host] = model
+	}
+
+	hostStatsValue, newDiags := types.MapValueFrom(ctx, types.ObjectType{AttrTypes: HostStatsModel{}.AttrTypes()}, hostStatsModel)
+	diags.Append(newDiags...)
+	*hostStats = hostStatsValue
+
+	taskResultsValue, newDiags := taskResultsListValue(ctx, rd.taskResults)
+	diags.Append(newDiags...)
+	*taskResults = taskResultsValue
+
+	var timingModel TimingModel
+
+	diags.Append(timingModel.Set(ctx, rd.timeline, rd.timingSlowestTasks)...)
+
+	timingValue, newDiags := types.ObjectValueFrom(ctx, TimingModel{}.AttrTypes(), timingModel)
+	diags.Append(newDiags...)
+	*timing = timingValue
+
 	return diags
 }
 
+func taskResultsListValue(ctx context.Context, results []ansible.TaskResult) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	resultsModel := make([]TaskResultModel, 0, len(results))
+	for _, result := range results {
+		var model TaskResultModel
+
+		diags.Append(model.Set(ctx, result)...)
+		resultsModel = append(resultsModel, model)
+	}
+
+	resultsValue, newDiags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: TaskResultModel{}.AttrTypes()}, resultsModel)
+	diags.Append(newDiags...)
+
+	return resultsValue, diags
+}
+
 func (rd navigatorRunData) artifactQueryPath(name string) path.Path {
 	if !rd.userArtifactQueries {
 		return path.Empty()
@@ -184,23 +394,57 @@ func preflightCheckPath(check navigator.PreflightCheck) path.Path {
 		return path.Root("execution_environment").AtName("enabled")
 	case navigator.CheckNavigatorResolve, navigator.CheckNavigatorBinary:
 		return path.Root("ansible_navigator_binary")
+	case navigator.CheckRunnerResolve, navigator.CheckRunnerBinary:
+		return path.Root("ansible_runner")
+	case navigator.CheckRequirementsSource:
+		return path.Root("requirements").AtName("source")
+	case navigator.CheckSSHCertificates:
+		return path.Root("ansible_options").AtName("private_keys")
+	case navigator.CheckImage:
+		return path.Root("execution_environment").AtName("image")
 	}
 
 	return path.Empty()
 }
 
-func setupStepPath(step navigator.SetupStep) path.Path {
+func inventoryEnvVar(name string) string {
+	return fmt.Sprintf("%s_%s", navigatorRunInventoryEnvVar, strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return unicode.ToUpper(r)
+		}
+
+		return '_'
+	}, name))
+}
+
+func setupStepPath(step navigator.SetupStep, name string) path.Path {
 	switch step {
 	case navigator.SetupPlaybook:
 		return path.Root("playbook")
 	case navigator.SetupInventories:
-		return path.Root("inventory")
+		if name == "" || name == navigatorRunName || name == navigatorRunPrevInventoryName {
+			return path.Root("inventory")
+		}
+
+		return path.Root("inventories").AtMapKey(name)
 	case navigator.SetupExtraVars:
 		return path.Root("ansible_options").AtName("extra_vars")
-	case navigator.SetupPrivateKeys:
+	case navigator.SetupPrivateKeys, navigator.SetupSSHAgent:
 		return path.Root("ansible_options").AtName("private_keys")
+	case navigator.SetupVaultPasswords:
+		return path.Root("ansible_options").AtName("vault_passwords")
 	case navigator.SetupKnownHosts:
 		return path.Root("ansible_options").AtName("known_hosts")
+	case navigator.SetupSSHProxy:
+		return path.Root("ansible_options").AtName("ssh_proxy")
+	case navigator.SetupRequirements:
+		return path.Root("requirements")
+	case navigator.SetupFiles:
+		if name == "" {
+			return path.Root("files")
+		}
+
+		return path.Root("files").AtMapKey(name)
 	case navigator.SetupDir, navigator.SetupSettings:
 		return path.Empty()
 	}
@@ -210,9 +454,37 @@ func setupStepPath(step navigator.SetupStep) path.Path {
 
 //nolint:cyclop
 func run(ctx context.Context, diags *diag.Diagnostics, runData *navigatorRunData) {
-	navRun := navigator.NewRun(runData.hostDir, runData.config)
-
 	ctx = tflog.SetField(ctx, "operation", runData.operation.String())
+
+	queued := time.Now()
+
+	release, err := runData.limiter.acquire(ctx)
+	if addError(diags, "Ansible navigator run not started", err) {
+		return
+	}
+	defer release()
+
+	// Time spent queued counts against the operation timeout.
+	if deadline, ok := ctx.Deadline(); ok {
+		runData.config.Settings.Timeout = min(runData.config.Settings.Timeout, time.Until(deadline)-navigatorRunTimeoutOverhead)
+	}
+
+	// The timeout is written in whole seconds, and zero would mean no timeout.
+	if runData.config.Settings.Timeout < time.Second {
+		addError(diags, "Ansible navigator run timed out", fmt.Errorf("%w, no time left to run the playbook after waiting %s for a run slot", context.DeadlineExceeded, time.Since(queued).Round(time.Second)))
+
+		return
+	}
+
+	// ctx is read when each line arrives, so output carries the fields set below.
+	navRun := navigator.NewRun(runData.hostDir, runData.config, navigator.WithOutputHandler(func(line string) {
+		tflog.Info(ctx, line)
+	}))
+
+	if short := navRun.ShortSensitiveValues(); short > 0 {
+		addWarning(diags, "Sensitive values not redacted", fmt.Errorf("%d sensitive values, such as vault passwords or private key passphrases, are shorter than %d characters and are not masked in output or logs", short, ansible.MinRedactLength))
+	}
+
 	ctx = tflog.SetField(ctx, "mode", navRun.Mode().String())
 	ctx = tflog.SetField(ctx, "workingDir", runData.config.WorkingDir)
 	ctx = tflog.SetField(ctx, "hostDir", navRun.HostDir())
@@ -220,18 +492,36 @@ func run(ctx context.Context, diags *diag.Diagnostics, runData *navigatorRunData
 
 	tflog.Debug(ctx, "starting run")
 
+	executed := false
+
 	defer func() {
+		if runData.export != nil && executed {
+			export := *runData.export
+			export.Name = fmt.Sprintf("%s-%s", export.Name, runData.operation)
+
+			exportPath, err := navRun.Export(export)
+			addWarning(diags, "Playbook artifact export failed", err)
+			runData.exportPath = exportPath
+		}
+
+		if runData.junitReportPath != "" && executed {
+			err := navRun.WriteJUnitReport(runData.junitReportPath)
+			addWarning(diags, "JUnit report failed", err)
+		}
+
 		if !runData.persistDir {
 			err := navRun.Cleanup()
 			addWarning(diags, "Run not cleaned up", err)
+		} else {
+			err := navRun.Close()
+			addWarning(diags, "Run not closed", err)
 		}
 	}()
 
 	navRun.SetEnv(navigatorRunOperationEnvVar, runData.operation.String())
-	navRun.SetEnv(navigatorRunInventoryEnvVar, navRun.InventoryPath(navigatorRunName))
 
-	if runData.operation == terraformOpUpdate {
-		navRun.SetEnv(navigatorRunPrevInventoryEnvVar, navRun.InventoryPath(navigatorRunPrevInventoryName))
+	for envVar, name := range runData.inventoryEnvVars {
+		navRun.SetEnv(envVar, navRun.InventoryPath(name))
 	}
 
 	tflog.Trace(ctx, "running preflight checks")
@@ -251,11 +541,11 @@ func run(ctx context.Context, diags *diag.Diagnostics, runData *navigatorRunData
 
 	tflog.Trace(ctx, "setting up run directory")
 
-	if err := navRun.Setup(); err != nil {
+	if err := navRun.Setup(ctx); err != nil {
 		for _, setupErr := range unwrapJoinedErrors(err) {
 			var typed *navigator.SetupError
 			if errors.As(setupErr, &typed) {
-				addPathError(diags, setupStepPath(typed.Step), "Setup failed", typed)
+				addPathError(diags, setupStepPath(typed.Step, typed.Name), "Setup failed", typed)
 
 				continue
 			}
@@ -270,20 +560,35 @@ func run(ctx context.Context, diags *diag.Diagnostics, runData *navigatorRunData
 
 	tflog.Trace(ctx, fmt.Sprintf("executing %s", navigator.Program))
 
+	executed = true
+
 	if err := navRun.Execute(ctx); err != nil {
-		runData.command = navRun.Command.String()
+		runData.command = navRun.Redact(navRun.Command.String())
 
 		summary := "Ansible navigator run failed"
+		details := attemptsSummary(navRun.Attempts)
+
 		if navRun.Status == ansible.StatusTimeout {
 			summary = "Ansible navigator run timed out"
+			details += completedTasksSummary(navRun)
 		}
 
-		addError(diags, summary, fmt.Errorf("%w\n\nOutput:\n%s", err, navRun.Output))
+		if navRun.Status == ansible.StatusCanceled {
+			summary = "Ansible navigator run canceled"
+			details += completedTasksSummary(navRun)
+		}
+
+		addError(diags, summary, fmt.Errorf("%w%s\n\nOutput:\n%s", err, details, navRun.Output))
 
 		return
 	}
 
-	runData.command = navRun.Command.String()
+	runData.command = navRun.Redact(navRun.Command.String())
+	runData.imageDigest = navRun.ImageDigest
+
+	if len(navRun.Attempts) > 1 {
+		addWarning(diags, "Ansible navigator run retried", fmt.Errorf("run succeeded after %d attempts%s", len(navRun.Attempts), attemptsSummary(navRun.Attempts)))
+	}
 
 	tflog.Trace(ctx, "querying playbook artifact")
 
@@ -300,6 +605,17 @@ func run(ctx context.Context, diags *diag.Diagnostics, runData *navigatorRunData
 		}
 	}
 
+	tflog.Trace(ctx, "parsing playbook artifact")
+
+	artifact, err := navRun.PlaybookArtifact()
+	if !addError(diags, "Failed to parse playbook artifact", err) {
+		runData.hostStats = artifact.HostStats
+		runData.taskResults = artifact.TaskResults()
+		runData.timeline = artifact.Timeline
+
+		logTimeline(ctx, artifact.Timeline, runData.timingSlowestTasks)
+	}
+
 	if runData.config.UseKnownHosts {
 		tflog.Trace(ctx, "reading known hosts")
 
@@ -313,6 +629,55 @@ func run(ctx context.Context, diags *diag.Diagnostics, runData *navigatorRunData
 	tflog.Debug(ctx, "run complete")
 }
 
+// logTimeline logs the slowest tasks only, a playbook may have thousands.
+func logTimeline(ctx context.Context, timeline ansible.Timeline, slowestTasks int) {
+	for _, task := range timeline.SlowestTasks(slowestTasks) {
+		tflog.Debug(ctx, "slow task", map[string]any{"play": task.Play, "task": task.Name, "duration": task.Duration().String()})
+	}
+
+	tflog.Debug(ctx, "playbook timing", map[string]any{"wall_time": timeline.WallTime().String()})
+}
+
+func attemptsSummary(attempts []navigator.Attempt) string {
+	if len(attempts) <= 1 {
+		return ""
+	}
+
+	var summary strings.Builder
+
+	summary.WriteString("\n\nAttempts:")
+
+	for _, attempt := range attempts {
+		summary.WriteString("\n- " + attempt.String())
+	}
+
+	return summary.String()
+}
+
+// completedTasksSummary lists the task results saved by an interrupted run,
+// which may not have got as far as a PLAY RECAP.
+func completedTasksSummary(navRun *navigator.Run) string {
+	artifact, err := navRun.PlaybookArtifact()
+	if err != nil {
+		return ""
+	}
+
+	var summary strings.Builder
+
+	summary.WriteString("\n\nCompleted tasks:")
+
+	results := artifact.TaskResults()
+	if len(results) == 0 {
+		summary.WriteString(" none")
+	}
+
+	for _, result := range results {
+		fmt.Fprintf(&summary, "\n- %s: %s on %s, %s", result.Play, result.Name, result.Host, result.Status)
+	}
+
+	return summary.String()
+}
+
 func unwrapJoinedErrors(err error) []error {
 	if err == nil {
 		return nil
@@ -0,0 +1,188 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+)
+
+const (
+	defaultSSHHostKeysTimeout = 5 * time.Minute
+)
+
+var (
+	_ datasource.DataSource = (*SSHHostKeysDataSource)(nil)
+)
+
+type SSHHostKeysDataSourceModel struct {
+	Host       types.String   `tfsdk:"host"`
+	Port       types.Int64    `tfsdk:"port"`
+	Algorithms types.List     `tfsdk:"algorithms"`
+	PublicKeys types.List     `tfsdk:"public_keys"`
+	KnownHosts types.List     `tfsdk:"known_hosts"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+func (m SSHHostKeysDataSourceModel) Value(ctx context.Context, scan *ansible.SSHHostKeyScan) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	*scan = ansible.SSHHostKeyScan{
+		Host: m.Host.ValueString(),
+		Port: ansible.DefaultSSHPort,
+	}
+
+	if !m.Port.IsNull() {
+		scan.Port = m.Port.ValueInt64()
+	}
+
+	if !m.Algorithms.IsNull() {
+		diags.Append(m.Algorithms.ElementsAs(ctx, &scan.Algorithms, false)...)
+	}
+
+	return diags
+}
+
+func (m *SSHHostKeysDataSourceModel) Set(ctx context.Context, scan ansible.SSHHostKeyScan, publicKeys []string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	knownHosts := make([]string, 0, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		knownHost, err := ansible.KnownHostsLine([]string{scan.Address()}, publicKey)
+		if addError(&diags, "Failed to build known hosts entry", err) {
+			return diags
+		}
+
+		knownHosts = append(knownHosts, knownHost)
+	}
+
+	var newDiags diag.Diagnostics
+
+	m.Port = types.Int64Value(scan.Port)
+
+	m.PublicKeys, newDiags = types.ListValueFrom(ctx, types.StringType, publicKeys)
+	diags.Append(newDiags...)
+
+	m.KnownHosts, newDiags = types.ListValueFrom(ctx, types.StringType, knownHosts)
+	diags.Append(newDiags...)
+
+	return diags
+}
+
+type SSHHostKeysDataSource struct{}
+
+func NewSSHHostKeysDataSource() datasource.DataSource { //nolint:ireturn
+	return &SSHHostKeysDataSource{}
+}
+
+func (d *SSHHostKeysDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_ssh_host_keys", req.ProviderTypeName)
+}
+
+func (d *SSHHostKeysDataSource) Schema(ctx context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	description := describe("Scan the SSH host keys offered by a host, for use with the `known_hosts` Ansible option instead of trust-on-first-use. The host is contacted once per algorithm without authenticating, and failed connections are retried until the read timeout (default `%s`) elapses, which allows for freshly booted machines.", defaultSSHHostKeysTimeout)
+	descriptions := map[string]attrDescription{
+		"host":        describe("Hostname or IP address of the host."),
+		"port":        describe("SSH port of the host. Defaults to `%d`.", ansible.DefaultSSHPort),
+		"algorithms":  describe("Host key algorithms to scan for. Algorithms not offered by the host are skipped, but at least one must be. Supported: `%s`. Defaults to `%s`.", strings.Join(ansible.SSHHostKeyAlgorithms(), "`, `"), strings.Join(ansible.DefaultSSHHostKeyAlgorithms(), "`, `")),
+		"public_keys": describe("Public keys offered by the host, in authorized keys format."),
+		"known_hosts": describe("SSH known host entries for the host, ready for the `known_hosts` Ansible option."),
+	}
+
+	resp.Schema = schema.Schema{
+		Description:         description.Description,
+		MarkdownDescription: description.MarkdownDescription,
+		Attributes: map[string]schema.Attribute{
+			"host": schema.StringAttribute{
+				Description:         descriptions["host"].Description,
+				MarkdownDescription: descriptions["host"].MarkdownDescription,
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"port": schema.Int64Attribute{
+				Description:         descriptions["port"].Description,
+				MarkdownDescription: descriptions["port"].MarkdownDescription,
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(ansible.MinPort, ansible.MaxPort),
+				},
+			},
+			"algorithms": schema.ListAttribute{
+				Description:         descriptions["algorithms"].Description,
+				MarkdownDescription: descriptions["algorithms"].MarkdownDescription,
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringIsSSHHostKeyAlgorithm()),
+				},
+			},
+			"public_keys": schema.ListAttribute{
+				Description:         descriptions["public_keys"].Description,
+				MarkdownDescription: descriptions["public_keys"].MarkdownDescription,
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"known_hosts": schema.ListAttribute{
+				Description:         descriptions["known_hosts"].Description,
+				MarkdownDescription: descriptions["known_hosts"].MarkdownDescription,
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"timeouts": timeouts.Attributes(ctx),
+		},
+	}
+}
+
+func (d *SSHHostKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data *SSHHostKeysDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, newDiags := terraformOperationDataSourceTimeout(ctx, data.Timeouts, defaultSSHHostKeysTimeout)
+	resp.Diagnostics.Append(newDiags...)
+
+	var scan ansible.SSHHostKeyScan
+
+	resp.Diagnostics.Append(data.Value(ctx, &scan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	publicKeys, err := ansible.ScanSSHHostKeys(ctx, scan)
+	if addPathError(&resp.Diagnostics, path.Root("host"), "Failed to scan SSH host keys", err) {
+		return
+	}
+
+	resp.Diagnostics.Append(data.Set(ctx, scan, publicKeys)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
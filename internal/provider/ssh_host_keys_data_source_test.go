@@ -0,0 +1,44 @@
+package provider_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/config"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+const (
+	sshHostKeysDataSource = "data.ansible_ssh_host_keys.test"
+)
+
+func TestAccSSHHostKeysDataSource_basic(t *testing.T) {
+	t.Parallel()
+
+	serverPublicKey, serverPrivateKey := testSSHKeygen(t)
+	port := testSSHServer(t, "", serverPrivateKey)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testTerraformConfig(t, filepath.Join("ssh_host_keys_data_source", "basic")),
+				ConfigVariables: testConfigVariables(t, config.Variables{
+					"ssh_port": config.IntegerVariable(port),
+				}),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(sshHostKeysDataSource, tfjsonpath.New("public_keys"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact(serverPublicKey),
+					})),
+					statecheck.ExpectKnownValue(sshHostKeysDataSource, tfjsonpath.New("known_hosts"), knownvalue.ListExact([]knownvalue.Check{
+						knownvalue.StringExact(fmt.Sprintf("[127.0.0.1]:%d %s", port, serverPublicKey)),
+					})),
+				},
+			},
+		},
+	})
+}
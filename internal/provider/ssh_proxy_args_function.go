@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+)
+
+var (
+	_ function.Function = (*SSHProxyArgsFunction)(nil)
+)
+
+func NewSSHProxyArgsFunction() function.Function { //nolint:ireturn
+	return &SSHProxyArgsFunction{}
+}
+
+type SSHProxyArgsFunction struct{}
+
+func (f *SSHProxyArgsFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "ssh_proxy_args"
+}
+
+func (f *SSHProxyArgsFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "SSH args for configuring Ansible to connect through the provider managed SSH proxy.",
+		Description:         "SSH command line arguments for configuring Ansible to integrate with provider managed known hosts and to tunnel connections through the 'ssh_proxy' bastion, which is subject to the same host key checking. Set or append to the 'ansible_ssh_common_args' Ansible variable or environment variable.",
+		MarkdownDescription: "SSH command line arguments for configuring Ansible to integrate with provider managed known hosts and to tunnel connections through the `ssh_proxy` bastion, which is subject to the same host key checking. Set or append to the `ansible_ssh_common_args` Ansible variable or environment variable.",
+
+		Parameters: []function.Parameter{
+			function.BoolParameter{
+				Name:                "accept_new",
+				Description:         "Accept and add new host keys ('StrictHostKeyChecking=accept_new') or only allow connections to hosts whose key(s) are already present ('StrictHostKeyChecking=yes').",
+				MarkdownDescription: "Accept and add new host keys (`StrictHostKeyChecking=accept_new`) or only allow connections to hosts whose key(s) are already present (`StrictHostKeyChecking=yes`).",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SSHProxyArgsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var acceptNew bool
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &acceptNew))
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, ansible.SSHProxyArgs(acceptNew)))
+}
@@ -0,0 +1,47 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+)
+
+func TestAccSSHProxyArgsFunction_yes(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::ansible::ssh_proxy_args(false)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact(`-o StrictHostKeyChecking=yes -o UserKnownHostsFile={{ ansible_ssh_known_hosts_file }} -o ProxyCommand="ssh -F {{ ansible_ssh_proxy_config_file }} -o StrictHostKeyChecking=yes -W [%h]:%p ssh-proxy"`)),
+				},
+			},
+		},
+	})
+}
+
+func TestAccSSHProxyArgsFunction_accept_new(t *testing.T) {
+	t.Parallel()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				output "test" {
+					value = provider::ansible::ssh_proxy_args(true)
+				}`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownOutputValue("test", knownvalue.StringExact(`-o StrictHostKeyChecking=accept-new -o UserKnownHostsFile={{ ansible_ssh_known_hosts_file }} -o ProxyCommand="ssh -F {{ ansible_ssh_proxy_config_file }} -o StrictHostKeyChecking=accept-new -W [%h]:%p ssh-proxy"`)),
+				},
+			},
+		},
+	})
+}
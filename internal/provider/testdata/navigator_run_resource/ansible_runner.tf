@@ -0,0 +1,27 @@
+resource "ansible_navigator_run" "test" {
+  playbook  = <<-EOT
+  - name: Test
+    hosts: localhost
+    gather_facts: false
+    become: false
+    tasks:
+    - name: Check environment
+      ansible.builtin.assert:
+        that:
+        - lookup('ansible.builtin.env', 'ANSIBLE_TF_OPERATION') == 'create'
+  EOT
+  inventory = "localhost ansible_connection=local"
+  ansible_runner = {
+    binary = var.ansible_runner_binary
+  }
+  artifact_queries = {
+    "task" = {
+      jq_filter = ".plays[0].tasks[0].task"
+    }
+  }
+}
+
+variable "ansible_runner_binary" {
+  type     = string
+  nullable = false
+}
@@ -0,0 +1,27 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - hosts: localhost
+    gather_facts: false
+    become: false
+  EOT
+  inventory                = "# localhost"
+  artifact_export = {
+    directory = var.export_directory
+    gzip      = true
+    retain    = 1
+  }
+  triggers = {
+    run = var.run
+  }
+}
+
+variable "export_directory" {
+  type     = string
+  nullable = false
+}
+
+variable "run" {
+  type     = string
+  nullable = false
+}
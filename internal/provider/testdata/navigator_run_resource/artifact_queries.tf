@@ -15,10 +15,14 @@ resource "ansible_navigator_run" "test" {
         src: /tmp/test
   EOT
   inventory                = "# localhost"
+  timing_slowest_tasks     = 1
   artifact_queries = {
     "stdout" = {
       jq_filter = ".stdout"
     }
+    "task_names" = {
+      jq_filter = ".plays[].tasks[].task"
+    }
     "file_contents" = {
       jq_filter = <<-EOT
       .plays[] | select(.name=="Test") |
@@ -33,6 +37,10 @@ output "file_contents" {
   value = base64decode(jsondecode(ansible_navigator_run.test.artifact_queries.file_contents.results[0]))
 }
 
+output "file_contents_result" {
+  value = base64decode(ansible_navigator_run.test.artifact_query_results.file_contents)
+}
+
 variable "file_contents" {
   type     = string
   nullable = false
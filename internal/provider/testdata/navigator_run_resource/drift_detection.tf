@@ -0,0 +1,29 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - name: Test
+    hosts: localhost
+    gather_facts: false
+    become: false
+    tasks:
+    - name: Write file
+      ansible.builtin.copy:
+        dest: ${var.file_path}
+        content: ${var.file_contents}
+  EOT
+  inventory                = "localhost ansible_connection=local"
+  drift_detection          = true
+  execution_environment = {
+    enabled = false
+  }
+}
+
+variable "file_path" {
+  type     = string
+  nullable = false
+}
+
+variable "file_contents" {
+  type     = string
+  nullable = false
+}
@@ -0,0 +1,10 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - hosts: localhost
+    gather_facts: false
+    become: false
+  EOT
+  inventory                = "# localhost"
+  cancel_grace_period      = "-1s"
+}
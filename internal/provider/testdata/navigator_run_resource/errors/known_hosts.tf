@@ -11,6 +11,7 @@ resource "ansible_navigator_run" "test" {
       "",
       "10.0.0.1 ssh-ed25519 invalid-public-key",
       "10.0.0.2 ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIL5L/oFlqWdgPhttkYs63jnETm6LQHlUen9G/CIbxR8p\nadditional-data",
+      "@trusted 10.0.0.3 ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIL5L/oFlqWdgPhttkYs63jnETm6LQHlUen9G/CIbxR8p",
     ]
   }
 }
@@ -0,0 +1,12 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - hosts: localhost
+    gather_facts: false
+    become: false
+  EOT
+  inventory                = "# localhost"
+  execution_environment = {
+    require_image_digest = true
+  }
+}
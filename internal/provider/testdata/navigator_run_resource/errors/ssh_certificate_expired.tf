@@ -0,0 +1,28 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - hosts: localhost
+    gather_facts: false
+    become: false
+  EOT
+  inventory                = "# localhost"
+  ansible_options = {
+    private_keys = [
+      {
+        name        = "test"
+        data        = var.client_private_key_data
+        certificate = var.client_certificate
+      },
+    ]
+  }
+}
+
+variable "client_private_key_data" {
+  type     = string
+  nullable = false
+}
+
+variable "client_certificate" {
+  type     = string
+  nullable = false
+}
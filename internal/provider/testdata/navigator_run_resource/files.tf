@@ -0,0 +1,49 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - hosts: localhost
+    gather_facts: false
+    become: false
+    roles:
+    - hello
+    tasks:
+    - ansible.builtin.assert:
+        that:
+        - hello == "role"
+        - greeting == "hello from files"
+        - lookup('ansible.builtin.template', 'greeting.j2') | trim == "hello from files"
+        - lookup('ansible.builtin.file', 'scripts/greet.sh') is search('hello')
+  EOT
+  inventory                = "# localhost"
+  execution_environment = {
+    enabled = var.ee_enabled
+  }
+  files = {
+    "roles/hello/tasks/main.yaml" = {
+      contents = <<-EOT
+      - ansible.builtin.set_fact:
+          hello: role
+      EOT
+    }
+    "group_vars/all.yaml" = {
+      contents = <<-EOT
+      greeting: hello from files
+      EOT
+    }
+    "templates/greeting.j2" = {
+      contents = "{{ greeting }}"
+    }
+    "scripts/greet.sh" = {
+      contents = <<-EOT
+      #!/bin/sh
+      echo hello
+      EOT
+      mode     = "0755"
+    }
+  }
+}
+
+variable "ee_enabled" {
+  type     = bool
+  nullable = false
+}
@@ -0,0 +1,38 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - hosts: all
+    gather_facts: false
+    become: false
+    tasks:
+    - ansible.builtin.assert:
+        that:
+        - "groups.all | sort == ['a', 'b']"
+        - "groups.constructed | sort == ['a', 'b']"
+        - lookup('ansible.builtin.env', 'ANSIBLE_TF_INVENTORY') != ''
+        - lookup('ansible.builtin.env', 'ANSIBLE_TF_INVENTORY_10_STATIC_YML') != ''
+        - lookup('ansible.builtin.env', 'ANSIBLE_TF_INVENTORY_20_CONSTRUCTED_YML') != ''
+  EOT
+  inventory = yamlencode({
+    all = {
+      hosts = {
+        a = { ansible_connection = "local" }
+      }
+    }
+  })
+  inventories = {
+    "10-static.yml" = yamlencode({
+      all = {
+        hosts = {
+          b = { ansible_connection = "local" }
+        }
+      }
+    })
+    "20-constructed.yml" = yamlencode({
+      plugin = "ansible.builtin.constructed"
+      groups = {
+        constructed = "true"
+      }
+    })
+  }
+}
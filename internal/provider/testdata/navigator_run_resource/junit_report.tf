@@ -0,0 +1,22 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - name: Test
+    hosts: localhost
+    gather_facts: false
+    become: false
+    tasks:
+    - name: Ping
+      ansible.builtin.ping:
+    - name: Skip
+      ansible.builtin.debug:
+      when: false
+  EOT
+  inventory                = "# localhost"
+  junit_report_path        = var.junit_report_path
+}
+
+variable "junit_report_path" {
+  type     = string
+  nullable = false
+}
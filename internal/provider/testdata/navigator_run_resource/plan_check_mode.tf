@@ -0,0 +1,21 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - name: Test
+    hosts: localhost
+    gather_facts: false
+    become: false
+    tasks:
+    - name: Write file
+      ansible.builtin.copy:
+        dest: /tmp/plan-check-mode
+        content: ${var.file_contents}
+  EOT
+  inventory                = "# localhost"
+  plan_check_mode          = true
+}
+
+variable "file_contents" {
+  type     = string
+  nullable = false
+}
@@ -0,0 +1,34 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - name: Test
+    hosts: localhost
+    gather_facts: false
+    become: false
+    tasks:
+    - name: Echo secret
+      ansible.builtin.debug:
+        msg: "secret is {{ secret }}"
+  EOT
+  inventory                = "# localhost"
+  ansible_options = {
+    extra_vars = yamlencode({
+      secret = var.secret
+    })
+  }
+  redact_values = [var.secret]
+  artifact_queries = {
+    "stdout" = {
+      jq_filter = ".stdout"
+    }
+    "msg" = {
+      jq_filter = ".plays[].tasks[] | select(.task==\"Echo secret\") | .res.msg"
+    }
+  }
+}
+
+variable "secret" {
+  type      = string
+  nullable  = false
+  sensitive = true
+}
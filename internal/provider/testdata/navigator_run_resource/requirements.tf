@@ -0,0 +1,27 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - hosts: localhost
+    gather_facts: false
+    become: false
+    tasks:
+    - ansible.builtin.include_role:
+        name: tftest.example.hello
+    - ansible.builtin.assert:
+        that:
+        - hello == "collection"
+        - lookup('ansible.builtin.env', 'ANSIBLE_COLLECTIONS_PATH') != ''
+  EOT
+  inventory                = "# localhost"
+  execution_environment = {
+    enabled = var.ee_enabled
+  }
+  requirements = {
+    source = "testdata/navigator_run_resource/requirements_collection"
+  }
+}
+
+variable "ee_enabled" {
+  type     = bool
+  nullable = false
+}
@@ -0,0 +1,27 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - name: Test
+    hosts: localhost
+    gather_facts: false
+    become: false
+    tasks:
+    - name: Check marker
+      ansible.builtin.stat:
+        path: "{{ playbook_dir }}/attempted"
+      register: marker
+    - name: Create marker
+      ansible.builtin.file:
+        path: "{{ playbook_dir }}/attempted"
+        state: touch
+    - name: Fail on first attempt
+      ansible.builtin.assert:
+        that: marker.stat.exists
+  EOT
+  inventory                = "localhost ansible_connection=local"
+  retry = {
+    max_attempts    = 2
+    initial_backoff = "1s"
+    retry_on        = ["failed"]
+  }
+}
@@ -0,0 +1,90 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - hosts: test
+    gather_facts: false
+    become: false
+    tasks:
+    - ansible.builtin.raw: test
+      register: connect
+    - ansible.builtin.assert:
+        that: connect.stdout == 'hello world!'
+  EOT
+  inventory = yamlencode({
+    all = {
+      hosts = {
+        test = {
+          ansible_host            = "127.0.0.1"
+          ansible_port            = var.ssh_port
+          ansible_ssh_common_args = "${provider::ansible::ssh_proxy_args(false)} -o IdentitiesOnly=yes -o AddKeysToAgent=no"
+        }
+      }
+    }
+  })
+  execution_environment = {
+    enabled = var.ee_enabled
+    container_options = [
+      "--net=host",
+    ]
+  }
+  ansible_options = {
+    private_keys = [
+      {
+        name = "bastion"
+        data = var.bastion_private_key_data
+      },
+      {
+        name = "test"
+        data = var.client_private_key_data
+      },
+    ]
+    known_hosts = [
+      "[127.0.0.1]:${var.ssh_port} ${var.server_public_key_data}",
+    ]
+    ssh_proxy = {
+      host             = "127.0.0.1"
+      port             = var.bastion_port
+      user             = "jump"
+      private_key_name = "bastion"
+      known_hosts = [
+        "[127.0.0.1]:${var.bastion_port} ${var.bastion_public_key_data}",
+      ]
+    }
+    host_key_checking = true
+  }
+}
+
+variable "ee_enabled" {
+  type     = bool
+  nullable = false
+}
+
+variable "client_private_key_data" {
+  type     = string
+  nullable = false
+}
+
+variable "server_public_key_data" {
+  type     = string
+  nullable = false
+}
+
+variable "ssh_port" {
+  type     = number
+  nullable = false
+}
+
+variable "bastion_private_key_data" {
+  type     = string
+  nullable = false
+}
+
+variable "bastion_public_key_data" {
+  type     = string
+  nullable = false
+}
+
+variable "bastion_port" {
+  type     = number
+  nullable = false
+}
@@ -0,0 +1,44 @@
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - hosts: localhost
+    gather_facts: false
+    become: false
+    tasks:
+    - ansible.builtin.assert:
+        that:
+        - default_secret == "${var.secret}"
+        - prod_secret == "${var.secret}"
+  EOT
+  inventory                = "# localhost"
+  ansible_options = {
+    extra_vars      = <<-EOT
+    default_secret: !vault |
+      ${indent(2, var.default_vault)}
+    prod_secret: !vault |
+      ${indent(2, var.prod_vault)}
+    EOT
+    vault_passwords = var.vault_passwords
+  }
+}
+
+variable "secret" {
+  type     = string
+  nullable = false
+}
+
+variable "default_vault" {
+  type     = string
+  nullable = false
+}
+
+variable "prod_vault" {
+  type     = string
+  nullable = false
+}
+
+variable "vault_passwords" {
+  type      = map(string)
+  nullable  = false
+  sensitive = true
+}
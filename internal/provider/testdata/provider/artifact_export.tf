@@ -0,0 +1,31 @@
+variable "base_run_directory" {
+  type     = string
+  nullable = false
+}
+
+variable "ansible_navigator_binary" {
+  type     = string
+  nullable = false
+}
+
+variable "export_directory" {
+  type     = string
+  nullable = false
+}
+
+provider "ansible" {
+  base_run_directory = var.base_run_directory
+  artifact_export = {
+    directory = var.export_directory
+  }
+}
+
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - hosts: localhost
+    gather_facts: false
+    become: false
+  EOT
+  inventory                = "# localhost"
+}
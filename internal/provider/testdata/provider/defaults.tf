@@ -0,0 +1,58 @@
+variable "base_run_directory" {
+  type     = string
+  nullable = false
+}
+
+variable "ansible_navigator_binary" {
+  type     = string
+  nullable = false
+}
+
+variable "default_timezone" {
+  type    = string
+  default = "America/New_York"
+}
+
+variable "ansible_options" {
+  type = object({
+    tags = list(string)
+  })
+  default = null
+}
+
+provider "ansible" {
+  base_run_directory = var.base_run_directory
+  defaults = {
+    execution_environment = {
+      pull_policy               = "missing"
+      environment_variables_set = {
+        "FROM_DEFAULTS" = "true"
+      }
+    }
+    ansible_options = {
+      tags = ["defaults"]
+    }
+    timezone = var.default_timezone
+  }
+}
+
+resource "ansible_navigator_run" "test" {
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - name: Test
+    hosts: localhost
+    gather_facts: false
+    become: false
+    tasks:
+    - name: Check environment
+      ansible.builtin.assert:
+        that:
+        - lookup('ansible.builtin.env', 'FROM_DEFAULTS') == 'true'
+      tags: [defaults, resource]
+  EOT
+  inventory                = "# localhost"
+  execution_environment = {
+    pull_policy = "tag"
+  }
+  ansible_options = var.ansible_options
+}
@@ -0,0 +1,15 @@
+resource "terraform_data" "this" {
+  input = 1
+}
+
+provider "ansible" {
+  max_concurrent_runs = terraform_data.this.output
+}
+
+data "ansible_navigator_run" "test" {
+  playbook  = <<-EOT
+  - hosts: localhost
+    become: false
+  EOT
+  inventory = "# localhost"
+}
@@ -0,0 +1,26 @@
+variable "base_run_directory" {
+  type     = string
+  nullable = false
+}
+
+variable "ansible_navigator_binary" {
+  type     = string
+  nullable = false
+}
+
+provider "ansible" {
+  base_run_directory  = var.base_run_directory
+  max_concurrent_runs = 1
+}
+
+data "ansible_navigator_run" "test" {
+  for_each = toset(["one", "two", "three"])
+
+  ansible_navigator_binary = var.ansible_navigator_binary
+  playbook                 = <<-EOT
+  - hosts: localhost
+    gather_facts: false
+    become: false
+  EOT
+  inventory                = "# localhost"
+}
@@ -0,0 +1,10 @@
+data "ansible_ssh_host_keys" "test" {
+  host       = "127.0.0.1"
+  port       = var.ssh_port
+  algorithms = ["ecdsa-sha2-nistp256", "ssh-ed25519"]
+}
+
+variable "ssh_port" {
+  type     = number
+  nullable = false
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
 	"github.com/marshallford/terraform-provider-ansible/pkg/ansible/navigator"
 )
@@ -66,6 +67,64 @@ func StringIsSSHPrivateKeyName() validator.String { //nolint:ireturn
 	return stringIsSSHPrivateKeyName()
 }
 
+type stringIsInventoryNameValidator struct{}
+
+var _ validator.String = (*stringIsInventoryNameValidator)(nil)
+
+func (v stringIsInventoryNameValidator) Description(_ context.Context) string {
+	return "string must be a valid inventory name"
+}
+
+func (v stringIsInventoryNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIsInventoryNameValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	err := ansible.ValidateInventoryName(req.ConfigValue.ValueString())
+	addPathError(&resp.Diagnostics, req.Path, "Not a valid inventory name", err)
+}
+
+func stringIsInventoryName() stringIsInventoryNameValidator {
+	return stringIsInventoryNameValidator{}
+}
+
+func StringIsInventoryName() validator.String { //nolint:ireturn
+	return stringIsInventoryName()
+}
+
+type stringIsVaultIDValidator struct{}
+
+var _ validator.String = (*stringIsVaultIDValidator)(nil)
+
+func (v stringIsVaultIDValidator) Description(_ context.Context) string {
+	return "string must be a valid vault ID"
+}
+
+func (v stringIsVaultIDValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIsVaultIDValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	err := ansible.ValidateVaultID(req.ConfigValue.ValueString())
+	addPathError(&resp.Diagnostics, req.Path, "Not a valid vault ID", err)
+}
+
+func stringIsVaultID() stringIsVaultIDValidator {
+	return stringIsVaultIDValidator{}
+}
+
+func StringIsVaultID() validator.String { //nolint:ireturn
+	return stringIsVaultID()
+}
+
 type stringIsSSHKnownHostValidator struct{}
 
 var _ validator.String = (*stringIsSSHKnownHostValidator)(nil)
@@ -239,3 +298,316 @@ func stringIsContainerImageName() stringIsContainerImageNameValidator {
 func StringIsContainerImageName() validator.String { //nolint:ireturn
 	return stringIsContainerImageName()
 }
+
+type stringIsCancelGracePeriodValidator struct{}
+
+var _ validator.String = (*stringIsCancelGracePeriodValidator)(nil)
+
+func (v stringIsCancelGracePeriodValidator) Description(_ context.Context) string {
+	return "string must be a duration that is not negative"
+}
+
+func (v stringIsCancelGracePeriodValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIsCancelGracePeriodValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	err := navigator.ValidateCancelGracePeriod(req.ConfigValue.ValueString())
+	addPathError(&resp.Diagnostics, req.Path, "Not a valid cancel grace period, use a duration such as '30s' or '0s'", err)
+}
+
+func stringIsCancelGracePeriod() stringIsCancelGracePeriodValidator {
+	return stringIsCancelGracePeriodValidator{}
+}
+
+func StringIsCancelGracePeriod() validator.String { //nolint:ireturn
+	return stringIsCancelGracePeriod()
+}
+
+type stringIsBackoffValidator struct{}
+
+var _ validator.String = (*stringIsBackoffValidator)(nil)
+
+func (v stringIsBackoffValidator) Description(_ context.Context) string {
+	return "string must be a positive duration"
+}
+
+func (v stringIsBackoffValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIsBackoffValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	err := navigator.ValidateBackoff(req.ConfigValue.ValueString())
+	addPathError(&resp.Diagnostics, req.Path, "Not a valid backoff duration, use a positive duration such as '30s' or '2m'", err)
+}
+
+func stringIsBackoff() stringIsBackoffValidator {
+	return stringIsBackoffValidator{}
+}
+
+func StringIsBackoff() validator.String { //nolint:ireturn
+	return stringIsBackoff()
+}
+
+type stringIsProjectFilePathValidator struct{}
+
+var _ validator.String = (*stringIsProjectFilePathValidator)(nil)
+
+func (v stringIsProjectFilePathValidator) Description(_ context.Context) string {
+	return "string must be a relative file path within the project directory"
+}
+
+func (v stringIsProjectFilePathValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIsProjectFilePathValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	err := navigator.ValidateProjectFilePath(req.ConfigValue.ValueString())
+	addPathError(&resp.Diagnostics, req.Path, "Not a valid project file path", err)
+}
+
+func stringIsProjectFilePath() stringIsProjectFilePathValidator {
+	return stringIsProjectFilePathValidator{}
+}
+
+func StringIsProjectFilePath() validator.String { //nolint:ireturn
+	return stringIsProjectFilePath()
+}
+
+type stringIsFileModeValidator struct{}
+
+var _ validator.String = (*stringIsFileModeValidator)(nil)
+
+func (v stringIsFileModeValidator) Description(_ context.Context) string {
+	return "string must be an octal file mode"
+}
+
+func (v stringIsFileModeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIsFileModeValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	err := navigator.ValidateFileMode(req.ConfigValue.ValueString())
+	addPathError(&resp.Diagnostics, req.Path, "Not a valid file mode, use octal notation such as '0644' or '0755'", err)
+}
+
+func stringIsFileMode() stringIsFileModeValidator {
+	return stringIsFileModeValidator{}
+}
+
+func StringIsFileMode() validator.String { //nolint:ireturn
+	return stringIsFileMode()
+}
+
+type stringIsSSHHostKeyAlgorithmValidator struct{}
+
+var _ validator.String = (*stringIsSSHHostKeyAlgorithmValidator)(nil)
+
+func (v stringIsSSHHostKeyAlgorithmValidator) Description(_ context.Context) string {
+	return "string must be a supported SSH host key algorithm"
+}
+
+func (v stringIsSSHHostKeyAlgorithmValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIsSSHHostKeyAlgorithmValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	err := ansible.ValidateSSHHostKeyAlgorithm(req.ConfigValue.ValueString())
+	addPathError(&resp.Diagnostics, req.Path, "Not a supported SSH host key algorithm", err)
+}
+
+func stringIsSSHHostKeyAlgorithm() stringIsSSHHostKeyAlgorithmValidator {
+	return stringIsSSHHostKeyAlgorithmValidator{}
+}
+
+func StringIsSSHHostKeyAlgorithm() validator.String { //nolint:ireturn
+	return stringIsSSHHostKeyAlgorithm()
+}
+
+type stringIsSSHCertificateValidator struct{}
+
+var _ validator.String = (*stringIsSSHCertificateValidator)(nil)
+
+func (v stringIsSSHCertificateValidator) Description(_ context.Context) string {
+	return "string must be an OpenSSH user certificate"
+}
+
+func (v stringIsSSHCertificateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringIsSSHCertificateValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	err := ansible.ValidateSSHCertificate(req.ConfigValue.ValueString())
+	addPathError(&resp.Diagnostics, req.Path, "Not a valid SSH certificate", err)
+}
+
+func stringIsSSHCertificate() stringIsSSHCertificateValidator {
+	return stringIsSSHCertificateValidator{}
+}
+
+func StringIsSSHCertificate() validator.String { //nolint:ireturn
+	return stringIsSSHCertificate()
+}
+
+type objectIsSSHPrivateKeyWithPassphraseValidator struct{}
+
+var _ validator.Object = (*objectIsSSHPrivateKeyWithPassphraseValidator)(nil)
+
+func (v objectIsSSHPrivateKeyWithPassphraseValidator) Description(_ context.Context) string {
+	return "passphrase must be set for, and decrypt, an encrypted private key"
+}
+
+func (v objectIsSSHPrivateKeyWithPassphraseValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v objectIsSSHPrivateKeyWithPassphraseValidator) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+
+	data, ok := attributes["data"].(types.String)
+	if !ok || data.IsUnknown() || data.IsNull() || ansible.ValidateSSHPrivateKey(data.ValueString()) != nil {
+		return
+	}
+
+	passphrase, ok := attributes["passphrase"].(types.String)
+	if !ok || passphrase.IsUnknown() {
+		return
+	}
+
+	err := ansible.ValidateSSHPrivateKeyPassphrase(data.ValueString(), passphrase.ValueString())
+	addPathError(&resp.Diagnostics, req.Path.AtName("passphrase"), "SSH private key passphrase is not valid", err)
+}
+
+func objectIsSSHPrivateKeyWithPassphrase() objectIsSSHPrivateKeyWithPassphraseValidator {
+	return objectIsSSHPrivateKeyWithPassphraseValidator{}
+}
+
+func ObjectIsSSHPrivateKeyWithPassphrase() validator.Object { //nolint:ireturn
+	return objectIsSSHPrivateKeyWithPassphrase()
+}
+
+type objectIsSSHPrivateKeyWithCertificateValidator struct{}
+
+var _ validator.Object = (*objectIsSSHPrivateKeyWithCertificateValidator)(nil)
+
+func (v objectIsSSHPrivateKeyWithCertificateValidator) Description(_ context.Context) string {
+	return "certificate must be issued for the private key"
+}
+
+func (v objectIsSSHPrivateKeyWithCertificateValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v objectIsSSHPrivateKeyWithCertificateValidator) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+
+	data, ok := attributes["data"].(types.String)
+	if !ok || data.IsUnknown() || data.IsNull() {
+		return
+	}
+
+	certificate, ok := attributes["certificate"].(types.String)
+	if !ok || certificate.IsUnknown() || certificate.IsNull() {
+		return
+	}
+
+	// A key that cannot be decrypted is reported by the passphrase validator.
+	passphrase, _ := attributes["passphrase"].(types.String)
+	if passphrase.IsUnknown() || ansible.ValidateSSHPrivateKeyPassphrase(data.ValueString(), passphrase.ValueString()) != nil {
+		return
+	}
+
+	err := ansible.ValidateSSHCertificateKey(data.ValueString(), passphrase.ValueString(), certificate.ValueString())
+	addPathError(&resp.Diagnostics, req.Path.AtName("certificate"), "SSH certificate does not match private key", err)
+}
+
+func objectIsSSHPrivateKeyWithCertificate() objectIsSSHPrivateKeyWithCertificateValidator {
+	return objectIsSSHPrivateKeyWithCertificateValidator{}
+}
+
+func ObjectIsSSHPrivateKeyWithCertificate() validator.Object { //nolint:ireturn
+	return objectIsSSHPrivateKeyWithCertificate()
+}
+
+type objectIsPinnedImageValidator struct{}
+
+var _ validator.Object = (*objectIsPinnedImageValidator)(nil)
+
+func (v objectIsPinnedImageValidator) Description(_ context.Context) string {
+	return "image must be pinned by digest when require_image_digest is set"
+}
+
+func (v objectIsPinnedImageValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v objectIsPinnedImageValidator) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsUnknown() || req.ConfigValue.IsNull() {
+		return
+	}
+
+	attributes := req.ConfigValue.Attributes()
+
+	requireDigest, ok := attributes["require_image_digest"].(types.Bool)
+	if !ok || !requireDigest.ValueBool() {
+		return
+	}
+
+	if enabled, ok := attributes["enabled"].(types.Bool); ok && !enabled.IsNull() && !enabled.IsUnknown() && !enabled.ValueBool() {
+		return
+	}
+
+	image, ok := attributes["image"].(types.String)
+	if !ok || image.IsUnknown() {
+		return
+	}
+
+	// the default image is pinned by tag
+	name := defaultNavigatorRunImage
+	if !image.IsNull() {
+		name = image.ValueString()
+	}
+
+	err := navigator.ValidateImagePinned(name)
+	addPathError(&resp.Diagnostics, req.Path.AtName("image"), "Container image is not pinned", err)
+}
+
+func objectIsPinnedImage() objectIsPinnedImageValidator {
+	return objectIsPinnedImageValidator{}
+}
+
+func ObjectIsPinnedImage() validator.Object { //nolint:ireturn
+	return objectIsPinnedImage()
+}
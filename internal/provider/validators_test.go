@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	schemavalidator "github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -29,6 +31,14 @@ func TestStringValidators_DescriptionsNotEmpty(t *testing.T) {
 			name:      "ssh_private_key_name",
 			validator: provider.StringIsSSHPrivateKeyName(),
 		},
+		{
+			name:      "inventory_name",
+			validator: provider.StringIsInventoryName(),
+		},
+		{
+			name:      "vault_id",
+			validator: provider.StringIsVaultID(),
+		},
 		{
 			name:      "ssh_known_host",
 			validator: provider.StringIsSSHKnownHost(),
@@ -53,6 +63,30 @@ func TestStringValidators_DescriptionsNotEmpty(t *testing.T) {
 			name:      "container_image_name",
 			validator: provider.StringIsContainerImageName(),
 		},
+		{
+			name:      "backoff",
+			validator: provider.StringIsBackoff(),
+		},
+		{
+			name:      "cancel_grace_period",
+			validator: provider.StringIsCancelGracePeriod(),
+		},
+		{
+			name:      "project_file_path",
+			validator: provider.StringIsProjectFilePath(),
+		},
+		{
+			name:      "file_mode",
+			validator: provider.StringIsFileMode(),
+		},
+		{
+			name:      "ssh_host_key_algorithm",
+			validator: provider.StringIsSSHHostKeyAlgorithm(),
+		},
+		{
+			name:      "ssh_certificate",
+			validator: provider.StringIsSSHCertificate(),
+		},
 	}
 
 	for _, test := range tests {
@@ -77,6 +111,8 @@ func TestStringValidators(t *testing.T) {
 	t.Parallel()
 
 	publicKey, privateKey := testSSHKeygen(t)
+	_, encryptedPrivateKey := testSSHKeygenWithPassphrase(t, "secret")
+	_, caPrivateKey := testSSHKeygen(t)
 
 	tests := []struct {
 		name          string
@@ -87,7 +123,7 @@ func TestStringValidators(t *testing.T) {
 		{
 			name:          "ssh_private_key",
 			validator:     provider.StringIsSSHPrivateKey(),
-			validValues:   []string{privateKey},
+			validValues:   []string{privateKey, encryptedPrivateKey},
 			invalidValues: []string{"not-a-private-key", ""},
 		},
 		{
@@ -96,6 +132,18 @@ func TestStringValidators(t *testing.T) {
 			validValues:   []string{"id-ed25519", "my-key-1"},
 			invalidValues: []string{"-bad-key-name", "bad-key-name-", "bad_key_name", "key!name"},
 		},
+		{
+			name:          "inventory_name",
+			validator:     provider.StringIsInventoryName(),
+			validValues:   []string{"static", "prod.aws_ec2.yml", "constructed.yml"},
+			invalidValues: []string{".hidden", "group_vars/all.yml", ""},
+		},
+		{
+			name:          "vault_id",
+			validator:     provider.StringIsVaultID(),
+			validValues:   []string{"default", "prod_east-1"},
+			invalidValues: []string{"prod@file", "../prod", ""},
+		},
 		{
 			name:          "ssh_known_host",
 			validator:     provider.StringIsSSHKnownHost(),
@@ -132,6 +180,42 @@ func TestStringValidators(t *testing.T) {
 			validValues:   []string{"ghcr.io/ansible/community-ansible-dev-tools:v26.7.1", "docker.io/library/alpine:3.21"},
 			invalidValues: []string{"not a valid image", ""},
 		},
+		{
+			name:          "backoff",
+			validator:     provider.StringIsBackoff(),
+			validValues:   []string{"5s", "1m30s", "500ms"},
+			invalidValues: []string{"5", "0s", "-1m", ""},
+		},
+		{
+			name:          "cancel_grace_period",
+			validator:     provider.StringIsCancelGracePeriod(),
+			validValues:   []string{"30s", "2m", "0s"},
+			invalidValues: []string{"30", "-1s", ""},
+		},
+		{
+			name:          "project_file_path",
+			validator:     provider.StringIsProjectFilePath(),
+			validValues:   []string{"roles/app/tasks/main.yaml", "templates/motd.j2", "group_vars/all.yaml"},
+			invalidValues: []string{"../secret", "/etc/passwd", "roles/", "playbook.yaml", ""},
+		},
+		{
+			name:          "file_mode",
+			validator:     provider.StringIsFileMode(),
+			validValues:   []string{"0644", "755", "0600"},
+			invalidValues: []string{"644a", "0999", "01777", "rw-r--r--", "", "000", "0000"},
+		},
+		{
+			name:          "ssh_host_key_algorithm",
+			validator:     provider.StringIsSSHHostKeyAlgorithm(),
+			validValues:   []string{"ssh-ed25519", "ecdsa-sha2-nistp256", "rsa-sha2-512"},
+			invalidValues: []string{"ssh-dss", "ed25519", ""},
+		},
+		{
+			name:          "ssh_certificate",
+			validator:     provider.StringIsSSHCertificate(),
+			validValues:   []string{testSSHCertificate(t, caPrivateKey, publicKey, time.Hour)},
+			invalidValues: []string{publicKey, "not-a-certificate", ""},
+		},
 	}
 
 	for _, test := range tests {
@@ -193,3 +277,208 @@ func TestStringValidators(t *testing.T) {
 		})
 	}
 }
+
+func TestObjectIsSSHPrivateKeyWithCertificate(t *testing.T) {
+	t.Parallel()
+
+	publicKey, privateKey := testSSHKeygen(t)
+	otherPublicKey, _ := testSSHKeygen(t)
+	_, caPrivateKey := testSSHKeygen(t)
+
+	attrTypes := map[string]attr.Type{
+		"name":        types.StringType,
+		"data":        types.StringType,
+		"certificate": types.StringType,
+	}
+
+	tests := map[string]struct {
+		certificate types.String
+		hasError    bool
+	}{
+		"matching": {
+			certificate: types.StringValue(testSSHCertificate(t, caPrivateKey, publicKey, time.Hour)),
+		},
+		"other_key": {
+			certificate: types.StringValue(testSSHCertificate(t, caPrivateKey, otherPublicKey, time.Hour)),
+			hasError:    true,
+		},
+		"null": {
+			certificate: types.StringNull(),
+		},
+		"unknown": {
+			certificate: types.StringUnknown(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			value := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"name":        types.StringValue("test"),
+				"data":        types.StringValue(privateKey),
+				"certificate": test.certificate,
+			})
+
+			response := schemavalidator.ObjectResponse{}
+			provider.ObjectIsSSHPrivateKeyWithCertificate().ValidateObject(
+				context.Background(),
+				schemavalidator.ObjectRequest{
+					Path:        path.Root("test_attribute"),
+					ConfigValue: value,
+				},
+				&response,
+			)
+
+			if response.Diagnostics.HasError() != test.hasError {
+				t.Fatalf("unexpected diagnostics error state: got %t, want %t", response.Diagnostics.HasError(), test.hasError)
+			}
+		})
+	}
+}
+
+func TestObjectIsSSHPrivateKeyWithPassphrase(t *testing.T) {
+	t.Parallel()
+
+	_, privateKey := testSSHKeygen(t)
+	_, encryptedPrivateKey := testSSHKeygenWithPassphrase(t, "secret")
+
+	attrTypes := map[string]attr.Type{
+		"name":       types.StringType,
+		"data":       types.StringType,
+		"passphrase": types.StringType,
+	}
+
+	tests := map[string]struct {
+		data       string
+		passphrase types.String
+		hasError   bool
+	}{
+		"unencrypted": {
+			data:       privateKey,
+			passphrase: types.StringNull(),
+		},
+		"encrypted": {
+			data:       encryptedPrivateKey,
+			passphrase: types.StringValue("secret"),
+		},
+		"missing": {
+			data:       encryptedPrivateKey,
+			passphrase: types.StringNull(),
+			hasError:   true,
+		},
+		"incorrect": {
+			data:       encryptedPrivateKey,
+			passphrase: types.StringValue("wrong"),
+			hasError:   true,
+		},
+		"unexpected": {
+			data:       privateKey,
+			passphrase: types.StringValue("secret"),
+			hasError:   true,
+		},
+		"unknown": {
+			data:       encryptedPrivateKey,
+			passphrase: types.StringUnknown(),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			value := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"name":       types.StringValue("test"),
+				"data":       types.StringValue(test.data),
+				"passphrase": test.passphrase,
+			})
+
+			response := schemavalidator.ObjectResponse{}
+			provider.ObjectIsSSHPrivateKeyWithPassphrase().ValidateObject(
+				context.Background(),
+				schemavalidator.ObjectRequest{
+					Path:        path.Root("test_attribute"),
+					ConfigValue: value,
+				},
+				&response,
+			)
+
+			if response.Diagnostics.HasError() != test.hasError {
+				t.Fatalf("unexpected diagnostics error state: got %t, want %t", response.Diagnostics.HasError(), test.hasError)
+			}
+		})
+	}
+}
+
+func TestObjectIsPinnedImage(t *testing.T) {
+	t.Parallel()
+
+	const pinned = "ghcr.io/ansible/community-ansible-dev-tools@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	attrTypes := map[string]attr.Type{
+		"enabled":              types.BoolType,
+		"image":                types.StringType,
+		"require_image_digest": types.BoolType,
+	}
+
+	tests := map[string]struct {
+		enabled       types.Bool
+		image         types.String
+		requireDigest types.Bool
+		hasError      bool
+	}{
+		"pinned": {
+			image:         types.StringValue(pinned),
+			requireDigest: types.BoolValue(true),
+		},
+		"tag": {
+			image:         types.StringValue("ghcr.io/ansible/community-ansible-dev-tools:v26.7.1"),
+			requireDigest: types.BoolValue(true),
+			hasError:      true,
+		},
+		"default_image": {
+			image:         types.StringNull(),
+			requireDigest: types.BoolValue(true),
+			hasError:      true,
+		},
+		"not_required": {
+			image:         types.StringValue("ghcr.io/ansible/community-ansible-dev-tools:v26.7.1"),
+			requireDigest: types.BoolNull(),
+		},
+		"disabled": {
+			enabled:       types.BoolValue(false),
+			image:         types.StringValue("ghcr.io/ansible/community-ansible-dev-tools:v26.7.1"),
+			requireDigest: types.BoolValue(true),
+		},
+		"unknown": {
+			image:         types.StringUnknown(),
+			requireDigest: types.BoolValue(true),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			value := types.ObjectValueMust(attrTypes, map[string]attr.Value{
+				"enabled":              test.enabled,
+				"image":                test.image,
+				"require_image_digest": test.requireDigest,
+			})
+
+			response := schemavalidator.ObjectResponse{}
+			provider.ObjectIsPinnedImage().ValidateObject(
+				context.Background(),
+				schemavalidator.ObjectRequest{
+					Path:        path.Root("test_attribute"),
+					ConfigValue: value,
+				},
+				&response,
+			)
+
+			if response.Diagnostics.HasError() != test.hasError {
+				t.Fatalf("unexpected diagnostics error state: got %t, want %t", response.Diagnostics.HasError(), test.hasError)
+			}
+		})
+	}
+}
@@ -1,11 +1,18 @@
 package ansible
 
-import "strings"
+import (
+	"io/fs"
+	"strings"
+)
 
 const (
 	PlaybookProgram              = "ansible-playbook"
+	GalaxyProgram                = "ansible-galaxy"
+	CollectionsPathEnvVar        = "ANSIBLE_COLLECTIONS_PATH"
+	RolesPathEnvVar              = "ANSIBLE_ROLES_PATH"
 	RunnerDefaultHostKeyChecking = false
 	SSHKnownHostsFileVar         = "ansible_ssh_known_hosts_file"
+	SSHProxyConfigFileVar        = "ansible_ssh_proxy_config_file"
 )
 
 type PlaybookOptions struct {
@@ -14,6 +21,8 @@ type PlaybookOptions struct {
 	StartAtTask   string
 	Limit         []string
 	Tags          []string
+	Check         bool
+	Diff          bool
 }
 
 func (o PlaybookOptions) Args() []string {
@@ -39,6 +48,14 @@ func (o PlaybookOptions) Args() []string {
 		args = append(args, "--tags", strings.Join(o.Tags, ","))
 	}
 
+	if o.Check {
+		args = append(args, "--check")
+	}
+
+	if o.Diff {
+		args = append(args, "--diff")
+	}
+
 	return args
 }
 
@@ -48,7 +65,32 @@ type Inventory struct {
 	Exclude  bool
 }
 
+type VaultPassword struct {
+	ID       string
+	Password string
+}
+
+// ProjectFile is written to Path, relative to the directory of the playbook.
+// The zero Mode leaves the default permissions.
+type ProjectFile struct {
+	Path     string
+	Contents string
+	Mode     fs.FileMode
+}
+
 type ExtraVarsFile struct {
 	Name     string
 	Contents string
 }
+
+// Requirements are installed with ansible-galaxy before the playbook runs.
+// Contents is a requirements.yml file, Source a local collection tarball or
+// source directory installed without contacting a Galaxy server.
+type Requirements struct {
+	Contents string
+	Source   string
+}
+
+func (r Requirements) IsEmpty() bool {
+	return r.Contents == "" && r.Source == ""
+}
@@ -31,6 +31,8 @@ func TestPlaybookOptionsArgs(t *testing.T) {
 				StartAtTask:   "task name",
 				Limit:         []string{"host1", "host2"},
 				Tags:          []string{"tag3", "tag4"},
+				Check:         true,
+				Diff:          true,
 			},
 			expected: []string{
 				"--force-handlers",
@@ -38,6 +40,8 @@ func TestPlaybookOptionsArgs(t *testing.T) {
 				"--start-at-task", "task name",
 				"--limit", "host1,host2",
 				"--tags", "tag3,tag4",
+				"--check",
+				"--diff",
 			},
 		},
 	}
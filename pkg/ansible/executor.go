@@ -1,7 +1,9 @@
 package ansible
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -15,11 +17,18 @@ const commandWaitDelay = 10 * time.Second
 // Command zero values follow os/exec: an empty Dir means the current working
 // directory, and a nil Env means the child inherits the current environment. A
 // non-nil but empty Env means the opposite, an environment with nothing in it.
+//
+// When the context is done the command's process group is interrupted, and
+// killed if still running after CancelGracePeriod. A context with a deadline
+// has the process interrupted CancelGracePeriod before it, at most half the
+// time remaining, so it is killed by the deadline rather than after it. A zero
+// CancelGracePeriod kills it straight away.
 type Command struct {
-	Name string
-	Args []string
-	Dir  string
-	Env  []string
+	Name              string
+	Args              []string
+	Dir               string
+	Env               []string
+	CancelGracePeriod time.Duration
 }
 
 func (c Command) String() string {
@@ -45,9 +54,20 @@ type Executor interface {
 	Run(ctx context.Context, command Command) ([]byte, error)
 }
 
+// OutputHandler receives command output one line at a time, without the line
+// ending. Stdout and stderr are interleaved in the order they were written.
+type OutputHandler func(line string)
+
+// StreamingExecutor hands output to a handler while the command runs, and still
+// returns the combined output once it exits.
+type StreamingExecutor interface {
+	Executor
+	Stream(ctx context.Context, command Command, handler OutputHandler) ([]byte, error)
+}
+
 type osExecutor struct{}
 
-var _ Executor = (*osExecutor)(nil)
+var _ StreamingExecutor = (*osExecutor)(nil)
 
 func OSExecutor() Executor { //nolint:ireturn
 	return osExecutor{}
@@ -66,10 +86,137 @@ func (osExecutor) Abs(path string) (string, error) {
 }
 
 func (osExecutor) Run(ctx context.Context, command Command) ([]byte, error) {
-	cmd := exec.CommandContext(ctx, command.Name, command.Args...) //nolint:gosec
+	cmd := command.osCommand()
+
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+
+	err := command.run(ctx, cmd)
+
+	return output.Bytes(), err
+}
+
+func (osExecutor) Stream(ctx context.Context, command Command, handler OutputHandler) ([]byte, error) {
+	cmd := command.osCommand()
+
+	// A single comparable writer for both streams means os/exec never calls
+	// Write concurrently.
+	writer := &lineWriter{handler: handler}
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+
+	err := command.run(ctx, cmd)
+	writer.flush()
+
+	return writer.output.Bytes(), err
+}
+
+// osCommand starts the command in its own process group, so that cancellation
+// reaches the processes it spawns, such as a container engine.
+func (c Command) osCommand() *exec.Cmd {
+	cmd := exec.Command(c.Name, c.Args...) //nolint:gosec,noctx
 	cmd.WaitDelay = commandWaitDelay
-	cmd.Dir = command.Dir
-	cmd.Env = command.Env
+	cmd.Dir = c.Dir
+	cmd.Env = c.Env
+	setProcessGroup(cmd)
+
+	return cmd
+}
+
+// interruptContext is done once the process should be interrupted: a grace
+// period before the deadline of ctx, or when ctx is canceled. The grace period
+// is limited to half the time remaining, so a short deadline still leaves the
+// process time to run.
+func (c Command) interruptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok || c.CancelGracePeriod <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	gracePeriod := min(c.CancelGracePeriod, time.Until(deadline)/2) //nolint:mnd
+
+	return context.WithDeadline(ctx, deadline.Add(-gracePeriod))
+}
+
+// run stands in for exec.CommandContext, which kills the process outright and
+// leaves it no chance to write its results.
+func (c Command) run(ctx context.Context, cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	interrupt, cancel := c.interruptContext(ctx)
+	defer cancel()
+
+	exited := make(chan struct{})
+	defer close(exited)
+
+	go func() {
+		select {
+		case <-exited:
+			return
+		case <-interrupt.Done():
+		}
+
+		if c.CancelGracePeriod <= 0 {
+			killProcessGroup(cmd.Process)
+
+			return
+		}
+
+		interruptProcessGroup(cmd.Process)
+
+		timer := time.NewTimer(c.CancelGracePeriod)
+		defer timer.Stop()
+
+		select {
+		case <-exited:
+		case <-timer.C:
+			killProcessGroup(cmd.Process)
+		case <-ctx.Done():
+			killProcessGroup(cmd.Process)
+		}
+	}()
+
+	if err := cmd.Wait(); err != nil {
+		if interrupt.Err() != nil {
+			return fmt.Errorf("%w, %w", interrupt.Err(), err)
+		}
+
+		return err //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+type lineWriter struct {
+	handler OutputHandler
+	output  bytes.Buffer
+	pending []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.output.Write(p)
+	w.pending = append(w.pending, p...)
+
+	for {
+		end := bytes.IndexByte(w.pending, '\n')
+		if end < 0 {
+			break
+		}
+
+		w.handler(string(bytes.TrimSuffix(w.pending[:end], []byte("\r"))))
+		w.pending = w.pending[end+1:]
+	}
+
+	return len(p), nil
+}
 
-	return cmd.CombinedOutput() //nolint:wrapcheck
+// flush hands over a final line that was not terminated by a newline.
+func (w *lineWriter) flush() {
+	if len(w.pending) > 0 {
+		w.handler(string(w.pending))
+		w.pending = nil
+	}
 }
@@ -0,0 +1,22 @@
+//go:build !unix
+
+package ansible
+
+import (
+	"os"
+	"os/exec"
+)
+
+func setProcessGroup(*exec.Cmd) {}
+
+// interruptProcessGroup falls back to SIGINT for the process alone, where
+// supported.
+func interruptProcessGroup(process *os.Process) {
+	if err := process.Signal(os.Interrupt); err != nil {
+		_ = process.Kill()
+	}
+}
+
+func killProcessGroup(process *os.Process) {
+	_ = process.Kill()
+}
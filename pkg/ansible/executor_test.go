@@ -0,0 +1,110 @@
+package ansible_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+)
+
+func TestOSExecutorStream(t *testing.T) {
+	t.Parallel()
+
+	exec, ok := ansible.OSExecutor().(ansible.StreamingExecutor)
+	if !ok {
+		t.Fatal("expected OS executor to support streaming")
+	}
+
+	var lines []string
+
+	command := ansible.Command{
+		Name: "sh",
+		Args: []string{"-c", `printf 'one\r\ntwo\n'; printf 'three' >&2`},
+	}
+
+	output, err := exec.Stream(context.Background(), command, func(line string) {
+		lines = append(lines, line)
+	})
+	if err != nil {
+		t.Fatalf("stream failed: %v", err)
+	}
+
+	if want := []string{"one", "two", "three"}; !slices.Equal(lines, want) {
+		t.Errorf("want lines %q, got %q", want, lines)
+	}
+
+	if want := "one\r\ntwo\nthree"; string(output) != want {
+		t.Errorf("want output %q, got %q", want, string(output))
+	}
+}
+
+func TestOSExecutorRunCanceled(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		script      string
+		gracePeriod time.Duration
+		wantOutput  string
+	}{
+		"interrupted": {
+			script:      `trap 'echo interrupted; exit 130' INT; echo started; while :; do sleep 0.1; done`,
+			gracePeriod: 10 * time.Second,
+			wantOutput:  "started\ninterrupted\n",
+		},
+		"interrupted_before_deadline": {
+			script:      `trap 'echo interrupted; exit 130' INT; echo started; while :; do sleep 0.1; done`,
+			gracePeriod: 200 * time.Millisecond,
+			wantOutput:  "started\ninterrupted\n",
+		},
+		"killed_after_grace_period": {
+			script:      `trap '' INT; echo started; while :; do sleep 0.1; done`,
+			gracePeriod: 100 * time.Millisecond,
+			wantOutput:  "started\n",
+		},
+		"killed_at_deadline": {
+			script:      `trap '' INT; echo started; while :; do sleep 0.1; done`,
+			gracePeriod: 10 * time.Second,
+			wantOutput:  "started\n",
+		},
+		"killed": {
+			script:     `trap 'echo interrupted; exit 130' INT; echo started; while :; do sleep 0.1; done`,
+			wantOutput: "started\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			const timeout = 500 * time.Millisecond
+
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			command := ansible.Command{
+				Name:              "sh",
+				Args:              []string{"-c", test.script},
+				CancelGracePeriod: test.gracePeriod,
+			}
+
+			start := time.Now()
+
+			output, err := ansible.OSExecutor().Run(ctx, command)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("want deadline exceeded error, got %v", err)
+			}
+
+			// The grace period is taken out of the timeout rather than added to it.
+			if elapsed := time.Since(start); elapsed > timeout+250*time.Millisecond {
+				t.Errorf("want run to stop within the timeout of %s, took %s", timeout, elapsed)
+			}
+
+			if string(output) != test.wantOutput {
+				t.Errorf("want output %q, got %q", test.wantOutput, string(output))
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+//go:build unix
+
+package ansible
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// interruptProcessGroup sends SIGINT, as a terminal would on Ctrl-C, which
+// ansible-navigator and ansible-runner handle by saving their results.
+func interruptProcessGroup(process *os.Process) {
+	_ = syscall.Kill(-process.Pid, syscall.SIGINT)
+}
+
+func killProcessGroup(process *os.Process) {
+	_ = syscall.Kill(-process.Pid, syscall.SIGKILL)
+}
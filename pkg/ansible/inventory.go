@@ -0,0 +1,283 @@
+package ansible
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	HostVar = "ansible_host"
+	PortVar = "ansible_port"
+	UserVar = "ansible_user"
+	MinPort = 1
+	MaxPort = 65535
+
+	allGroup       = "all"
+	ungroupedGroup = "ungrouped"
+)
+
+var inventoryGroupNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// InventoryHost is a host of a generated inventory. Host, Port and User are
+// shorthands for the connection variables of the same name.
+type InventoryHost struct {
+	Name   string
+	Host   string
+	Port   int64
+	User   string
+	Groups []string
+	Vars   map[string]any
+}
+
+type InventoryGroup struct {
+	Name     string
+	Hosts    []string
+	Children []string
+	Vars     map[string]any
+}
+
+func ValidateInventoryHostName(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("%w, inventory host name cannot be empty", ErrValidation)
+	}
+
+	if strings.ContainsFunc(name, func(r rune) bool { return unicode.IsSpace(r) || r == ',' }) {
+		return fmt.Errorf("%w, inventory host name cannot contain whitespace or commas", ErrValidation)
+	}
+
+	return nil
+}
+
+func ValidateInventoryGroupName(name string) error {
+	if !inventoryGroupNameRegex.MatchString(name) {
+		return fmt.Errorf("%w, inventory group name must start with a letter or underscore and only contain letters (A-Z, a-z), numbers (0-9), and underscores (_)", ErrValidation)
+	}
+
+	if name == allGroup || name == ungroupedGroup {
+		return fmt.Errorf("%w, inventory group name '%s' is reserved", ErrValidation, name)
+	}
+
+	return nil
+}
+
+// BuildInventory renders a YAML inventory. Every host and its variables are
+// defined under 'all', and every group is a direct child of 'all' that refers
+// to its hosts and child groups by name.
+func BuildInventory(hosts []InventoryHost, groups []InventoryGroup, vars map[string]any) (string, error) {
+	var errs []error
+
+	hostsFormat := map[string]any{}
+	groupsFormat := map[string]map[string]any{}
+	children := map[string][]string{}
+
+	group := func(name string) map[string]any {
+		if _, ok := groupsFormat[name]; !ok {
+			groupsFormat[name] = map[string]any{}
+		}
+
+		return groupsFormat[name]
+	}
+
+	addHost := func(groupName string, hostName string) {
+		groupFormat := group(groupName)
+		if _, ok := groupFormat["hosts"]; !ok {
+			groupFormat["hosts"] = map[string]any{}
+		}
+
+		groupFormat["hosts"].(map[string]any)[hostName] = map[string]any{} //nolint:forcetypeassert
+	}
+
+	for _, groupConfig := range groups {
+		if err := ValidateInventoryGroupName(groupConfig.Name); err != nil {
+			errs = append(errs, fmt.Errorf("group '%s', %w", groupConfig.Name, err))
+
+			continue
+		}
+
+		groupFormat := group(groupConfig.Name)
+		if len(groupConfig.Vars) > 0 {
+			groupFormat["vars"] = groupConfig.Vars
+		}
+
+		children[groupConfig.Name] = append(children[groupConfig.Name], groupConfig.Children...)
+	}
+
+	for _, host := range hosts {
+		if err := ValidateInventoryHostName(host.Name); err != nil {
+			errs = append(errs, fmt.Errorf("host '%s', %w", host.Name, err))
+
+			continue
+		}
+
+		if _, ok := hostsFormat[host.Name]; ok {
+			errs = append(errs, fmt.Errorf("%w, host '%s' is defined more than once", ErrValidation, host.Name))
+
+			continue
+		}
+
+		hostVars, err := host.vars()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("host '%s', %w", host.Name, err))
+
+			continue
+		}
+
+		hostsFormat[host.Name] = hostVars
+
+		for _, groupName := range host.Groups {
+			if err := ValidateInventoryGroupName(groupName); err != nil {
+				errs = append(errs, fmt.Errorf("host '%s' group '%s', %w", host.Name, groupName, err))
+
+				continue
+			}
+
+			addHost(groupName, host.Name)
+		}
+	}
+
+	for _, groupConfig := range groups {
+		for _, hostName := range groupConfig.Hosts {
+			if _, ok := hostsFormat[hostName]; !ok {
+				errs = append(errs, fmt.Errorf("%w, group '%s' refers to undefined host '%s'", ErrValidation, groupConfig.Name, hostName))
+
+				continue
+			}
+
+			addHost(groupConfig.Name, hostName)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(children)) {
+		for _, child := range children[name] {
+			if _, ok := groupsFormat[child]; !ok {
+				errs = append(errs, fmt.Errorf("%w, group '%s' refers to undefined child group '%s'", ErrValidation, name, child))
+
+				continue
+			}
+
+			groupFormat := group(name)
+			if _, ok := groupFormat["children"]; !ok {
+				groupFormat["children"] = map[string]any{}
+			}
+
+			groupFormat["children"].(map[string]any)[child] = map[string]any{} //nolint:forcetypeassert
+		}
+	}
+
+	if cycle := inventoryGroupCycle(children); cycle != nil {
+		errs = append(errs, fmt.Errorf("%w, group cycle %s", ErrValidation, strings.Join(cycle, " -> ")))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return "", err
+	}
+
+	allFormat := map[string]any{}
+	if len(vars) > 0 {
+		allFormat["vars"] = vars
+	}
+
+	if len(hostsFormat) > 0 {
+		allFormat["hosts"] = hostsFormat
+	}
+
+	if len(groupsFormat) > 0 {
+		allFormat["children"] = groupsFormat
+	}
+
+	data, err := yaml.Marshal(map[string]any{allGroup: allFormat})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal inventory, %w", err)
+	}
+
+	return string(data), nil
+}
+
+func (h InventoryHost) vars() (map[string]any, error) {
+	hostVars := maps.Clone(h.Vars)
+	if hostVars == nil {
+		hostVars = map[string]any{}
+	}
+
+	shorthands := map[string]any{}
+	if h.Host != "" {
+		shorthands[HostVar] = h.Host
+	}
+
+	if h.Port != 0 {
+		if h.Port < MinPort || h.Port > MaxPort {
+			return nil, fmt.Errorf("%w, port must be between %d and %d", ErrValidation, MinPort, MaxPort)
+		}
+
+		shorthands[PortVar] = h.Port
+	}
+
+	if h.User != "" {
+		shorthands[UserVar] = h.User
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(shorthands)) {
+		if _, ok := hostVars[name]; ok {
+			return nil, fmt.Errorf("%w, variable '%s' conflicts with the shorthand for it", ErrValidation, name)
+		}
+
+		hostVars[name] = shorthands[name]
+	}
+
+	return hostVars, nil
+}
+
+// inventoryGroupCycle returns the groups of the first cycle found, starting and
+// ending with the same group.
+func inventoryGroupCycle(children map[string][]string) []string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := map[string]int{}
+
+	var path []string
+
+	var visit func(name string) []string
+	visit = func(name string) []string {
+		switch state[name] {
+		case visiting:
+			start := slices.Index(path, name)
+
+			return append(slices.Clone(path[start:]), name)
+		case visited:
+			return nil
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+
+		for _, child := range children[name] {
+			if cycle := visit(child); cycle != nil {
+				return cycle
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[name] = visited
+
+		return nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(children)) {
+		if cycle := visit(name); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
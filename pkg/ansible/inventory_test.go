@@ -0,0 +1,117 @@
+package ansible_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+)
+
+func TestBuildInventory(t *testing.T) {
+	t.Parallel()
+
+	hosts := []ansible.InventoryHost{
+		{Name: "web1", Host: "10.0.0.1", Port: 2222, User: "admin", Groups: []string{"web"}},
+		{Name: "db1", Host: "10.0.0.2", Vars: map[string]any{"role": "primary"}},
+	}
+
+	groups := []ansible.InventoryGroup{
+		{Name: "web", Vars: map[string]any{"http_port": 80}},
+		{Name: "db", Hosts: []string{"db1"}},
+		{Name: "prod", Children: []string{"web", "db"}},
+	}
+
+	got, err := ansible.BuildInventory(hosts, groups, map[string]any{"env": "prod"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `all:
+    children:
+        db:
+            hosts:
+                db1: {}
+        prod:
+            children:
+                db: {}
+                web: {}
+        web:
+            hosts:
+                web1: {}
+            vars:
+                http_port: 80
+    hosts:
+        db1:
+            ansible_host: 10.0.0.2
+            role: primary
+        web1:
+            ansible_host: 10.0.0.1
+            ansible_port: 2222
+            ansible_user: admin
+    vars:
+        env: prod
+`
+
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestBuildInventoryErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		hosts  []ansible.InventoryHost
+		groups []ansible.InventoryGroup
+		want   string
+	}{
+		"host_name": {
+			hosts: []ansible.InventoryHost{{Name: "web 1"}},
+			want:  "cannot contain whitespace",
+		},
+		"group_name": {
+			groups: []ansible.InventoryGroup{{Name: "web-servers"}},
+			want:   "must start with a letter or underscore",
+		},
+		"reserved_group_name": {
+			groups: []ansible.InventoryGroup{{Name: "all"}},
+			want:   "'all' is reserved",
+		},
+		"undefined_host": {
+			groups: []ansible.InventoryGroup{{Name: "web", Hosts: []string{"web1"}}},
+			want:   "undefined host 'web1'",
+		},
+		"undefined_child": {
+			groups: []ansible.InventoryGroup{{Name: "prod", Children: []string{"web"}}},
+			want:   "undefined child group 'web'",
+		},
+		"cycle": {
+			groups: []ansible.InventoryGroup{
+				{Name: "a", Children: []string{"b"}},
+				{Name: "b", Children: []string{"c"}},
+				{Name: "c", Children: []string{"a"}},
+			},
+			want: "group cycle a -> b -> c -> a",
+		},
+		"shorthand_conflict": {
+			hosts: []ansible.InventoryHost{{Name: "web1", Host: "10.0.0.1", Vars: map[string]any{"ansible_host": "10.0.0.2"}}},
+			want:  "'ansible_host' conflicts",
+		},
+		"port": {
+			hosts: []ansible.InventoryHost{{Name: "web1", Port: 70000}},
+			want:  "port must be between",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ansible.BuildInventory(test.hosts, test.groups, nil)
+			if !errors.Is(err, ansible.ErrValidation) || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("expected validation error containing %q, got %v", test.want, err)
+			}
+		})
+	}
+}
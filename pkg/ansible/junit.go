@@ -0,0 +1,117 @@
+package ansible
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// JUnitReport converts the artifact into JUnit XML, with a test suite per play
+// and a test case per task and host. Failed tasks are failures, unreachable
+// hosts are errors and skipped tasks are skipped. Failures with ignore_errors
+// pass, as they do not fail the playbook. Suite times add up the test cases.
+func JUnitReport(artifact *PlaybookArtifact) ([]byte, error) {
+	report := junitTestSuites{
+		Name:   PlaybookProgram,
+		Suites: make([]junitTestSuite, 0, len(artifact.Plays)),
+	}
+
+	var reportTime time.Duration
+
+	for _, play := range artifact.Plays {
+		suite := junitTestSuite{
+			Name:  play.Name,
+			Cases: make([]junitTestCase, 0, len(play.Tasks)),
+		}
+
+		var suiteTime time.Duration
+
+		for _, result := range play.Tasks {
+			testCase := junitTestCase{
+				Name:      result.Name,
+				ClassName: result.Host,
+				Time:      junitTime(result.Duration),
+			}
+
+			switch result.Status {
+			case TaskStatusOK, TaskStatusChanged, TaskStatusIgnored:
+			case TaskStatusFailed:
+				testCase.Failure = &junitProblem{Message: result.Message, Type: result.Status.String(), Text: result.Action}
+				suite.Failures++
+			case TaskStatusUnreachable:
+				testCase.Error = &junitProblem{Message: result.Message, Type: result.Status.String(), Text: result.Action}
+				suite.Errors++
+			case TaskStatusSkipped:
+				testCase.Skipped = &junitSkipped{Message: result.Message}
+				suite.Skipped++
+			}
+
+			suite.Cases = append(suite.Cases, testCase)
+			suiteTime += result.Duration
+		}
+
+		suite.Tests = len(suite.Cases)
+		suite.Time = junitTime(suiteTime)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		reportTime += suiteTime
+
+		report.Suites = append(report.Suites, suite)
+	}
+
+	report.Time = junitTime(reportTime)
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to build JUnit report, %w", err)
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+func junitTime(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
@@ -0,0 +1,46 @@
+package ansible_test
+
+import (
+	"testing"
+
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+)
+
+func TestJUnitReport(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"results": `{"status":"failed","stdout":[],"plays":[
+			{"name":"Test","tasks":[
+				{"task":"Ping","task_action":"ansible.builtin.ping","host":"a","res":{"changed":false},"start":"2026-01-01T00:00:00.000000","end":"2026-01-01T00:00:00.250000"},
+				{"task":"Copy","task_action":"ansible.builtin.copy","host":"a","res":{"changed":true},"start":"2026-01-01T00:00:01.000000","end":"2026-01-01T00:00:02.500000"},
+				{"task":"Copy","task_action":"ansible.builtin.copy","host":"b","res":{"failed":true,"msg":"Destination <dir> not writable"},"start":"2026-01-01T00:00:01.000000","end":"2026-01-01T00:00:01.500000"},
+				{"task":"Check","task_action":"ansible.builtin.command","host":"a","ignore_errors":true,"res":{"failed":true,"msg":"non-zero return code"}},
+				{"task":"Skip","task_action":"ansible.builtin.debug","host":"a","res":{"skipped":true,"msg":"Conditional result was False"}},
+				{"task":"Ping","task_action":"ansible.builtin.ping","host":"c","res":{"unreachable":true,"msg":"Failed to connect to the host via ssh"}}
+			]},
+			{"name":"Cleanup","tasks":[
+				{"task":"Remove","task_action":"ansible.builtin.file","host":"a","res":{"changed":true},"start":"2026-01-01T00:00:03.000000","end":"2026-01-01T00:00:04.000000"}
+			]}
+		]}`,
+		"empty": `{"status":"successful","stdout":[],"plays":[]}`,
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			artifact, err := ansible.ParsePlaybookArtifact([]byte(input))
+			if err != nil {
+				t.Fatalf("failed to parse playbook artifact: %v", err)
+			}
+
+			report, err := ansible.JUnitReport(artifact)
+			if err != nil {
+				t.Fatalf("failed to build JUnit report: %v", err)
+			}
+
+			assertGolden(t, "junit/"+name+".xml", string(report))
+		})
+	}
+}
@@ -13,21 +13,23 @@ func (r *Run) navigatorCommand() ansible.Command {
 		Name: r.resolved.navigatorBinary,
 		Args: []string{
 			"run",
-			r.navigatorJoin(playbookFilename),
+			r.navigatorJoin(r.playbookFile()),
 			"--playbook-artifact-save-as",
 			r.navigatorJoin(playbookArtifactFilename),
 			"--log-file",
 			r.navigatorJoin(navigatorLogFilename),
 		},
-		Dir: r.resolved.workingDir,
-		Env: r.exec.Environ(),
+		Dir:               r.resolved.workingDir,
+		Env:               r.exec.Environ(),
+		CancelGracePeriod: r.config.CancelGracePeriod,
 	}
 
-	command = command.AppendArgs(r.navigatorArgs()...)
+	command = command.AppendArgs(r.playbookArgs()...)
 	command = command.AppendEnv("ANSIBLE_NAVIGATOR_CONFIG", r.navigatorJoin(navigatorSettingsFilename))
 
-	for _, name := range slices.Sorted(maps.Keys(r.env)) {
-		command = command.AppendEnv(name, r.env[name])
+	env := r.environment()
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		command = command.AppendEnv(name, env[name])
 	}
 
 	if r.config.HostKeyChecking != ansible.RunnerDefaultHostKeyChecking {
@@ -37,7 +39,38 @@ func (r *Run) navigatorCommand() ansible.Command {
 	return command
 }
 
-func (r *Run) navigatorArgs() []string {
+// runnerCommand leaves the playbook arguments and environment to the env
+// directory written during Setup. The working directory doubles as the runner
+// project directory, so ansible.cfg and relative paths resolve as they would
+// for navigator.
+func (r *Run) runnerCommand() ansible.Command {
+	return ansible.Command{
+		Name: r.resolved.runnerBinary,
+		Args: []string{
+			"run",
+			r.HostDir(),
+			"--ident",
+			runnerIdent,
+			"--project-dir",
+			r.resolved.workingDir,
+			"--playbook",
+			r.hostJoin(r.playbookFile()),
+		},
+		Dir:               r.resolved.workingDir,
+		Env:               r.exec.Environ(),
+		CancelGracePeriod: r.config.CancelGracePeriod,
+	}
+}
+
+func (r *Run) command() ansible.Command {
+	if r.config.mode() == ModeRunner {
+		return r.runnerCommand()
+	}
+
+	return r.navigatorCommand()
+}
+
+func (r *Run) playbookArgs() []string {
 	var args []string
 
 	for _, inventory := range r.config.Inventories {
@@ -55,12 +88,24 @@ func (r *Run) navigatorArgs() []string {
 	args = append(args, r.config.Options.Args()...)
 
 	for _, key := range r.config.PrivateKeys {
+		if r.config.usesSSHAgent(key) {
+			continue
+		}
+
 		args = append(args, "--private-key", r.playbookJoin(privateKeysDir, key.Name))
 	}
 
-	if r.config.UseKnownHosts {
+	for _, password := range r.config.VaultPasswords {
+		args = append(args, "--vault-id", fmt.Sprintf("%s@%s", password.ID, r.playbookJoin(vaultPasswordDir, password.ID)))
+	}
+
+	if r.config.usesKnownHosts() {
 		args = append(args, "--extra-vars", fmt.Sprintf("%s=%s", ansible.SSHKnownHostsFileVar, r.playbookJoin(knownHostsDir, knownHostsFile)))
 	}
 
+	if !r.config.SSHProxy.IsEmpty() {
+		args = append(args, "--extra-vars", fmt.Sprintf("%s=%s", ansible.SSHProxyConfigFileVar, r.playbookJoin(sshProxyDir, sshProxyConfig)))
+	}
+
 	return args
 }
@@ -8,6 +8,11 @@ const (
 	CheckPlaybook
 	CheckNavigatorResolve
 	CheckNavigatorBinary
+	CheckRequirementsSource
+	CheckRunnerResolve
+	CheckRunnerBinary
+	CheckSSHCertificates
+	CheckImage
 )
 
 type SetupStep int
@@ -18,8 +23,13 @@ const (
 	SetupInventories
 	SetupExtraVars
 	SetupPrivateKeys
+	SetupVaultPasswords
 	SetupKnownHosts
 	SetupSettings
+	SetupRequirements
+	SetupFiles
+	SetupSSHAgent
+	SetupSSHProxy
 )
 
 type runError struct {
@@ -53,12 +63,19 @@ type SetupError struct {
 	runError
 
 	Step SetupStep
+	// Name identifies the failing item for steps that write several, such as
+	// an inventory name. Empty otherwise.
+	Name string
 }
 
 func newSetupError(step SetupStep, message string, err error) *SetupError {
 	return &SetupError{runError: runError{Message: message, Err: err}, Step: step}
 }
 
+func newSetupItemError(step SetupStep, name string, message string, err error) *SetupError {
+	return &SetupError{runError: runError{Message: message, Err: err}, Step: step, Name: name}
+}
+
 type QueryError struct {
 	runError
 
@@ -0,0 +1,183 @@
+package navigator
+
+import (
+	"cmp"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+	"github.com/spf13/afero"
+)
+
+const gzipExtension = ".gz"
+
+// ArtifactExport copies the playbook artifact and navigator log of a run out
+// of the run directory, which is removed once the run completes.
+type ArtifactExport struct {
+	Dir string
+	// Name identifies the run and prefixes the exported file names.
+	Name string
+	// Group prefixes the names of every export that retention counts together.
+	Group string
+	Gzip  bool
+	// Retain is the number of exports kept per group, zero keeps them all.
+	Retain int
+}
+
+func (e ArtifactExport) path(filename string) string {
+	name := fmt.Sprintf("%s.%s", e.Name, filename)
+	if e.Gzip {
+		name += gzipExtension
+	}
+
+	return filepath.Join(e.Dir, name)
+}
+
+// Export copies the playbook artifact and, when present, the navigator log,
+// with sensitive values masked, then removes the oldest exports of the group
+// beyond Retain. Returns the path of the exported playbook artifact, also when
+// only the removal fails.
+func (r *Run) Export(export ArtifactExport) (string, error) {
+	if err := r.fs.MkdirAll(export.Dir, dirPermissions); err != nil {
+		return "", fmt.Errorf("failed to create artifact export directory, %w", err)
+	}
+
+	artifactPath := export.path(playbookArtifactFilename)
+	if err := r.exportFile(r.hostJoin(playbookArtifactFilename), artifactPath, export.Gzip, r.redactor.RedactJSON); err != nil {
+		return "", fmt.Errorf("failed to export playbook artifact, %w", err)
+	}
+
+	// ansible-runner runs have no navigator log
+	err := r.exportFile(r.hostJoin(navigatorLogFilename), export.path(navigatorLogFilename), export.Gzip, r.redactLog)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("failed to export %s log, %w", Program, err)
+	}
+
+	if err := r.pruneExports(export); err != nil {
+		return artifactPath, err
+	}
+
+	return artifactPath, nil
+}
+
+func (r *Run) exportFile(src string, dest string, compress bool, redact func([]byte) ([]byte, error)) error {
+	contents, err := afero.ReadFile(r.fs, src)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	contents, err = redact(contents)
+	if err != nil {
+		return err
+	}
+
+	file, err := r.fs.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, filePermissions)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	var writer io.WriteCloser = file
+	if compress {
+		writer = gzip.NewWriter(file)
+	}
+
+	_, copyErr := writer.Write(contents)
+
+	if compress {
+		copyErr = errors.Join(copyErr, writer.Close())
+	}
+
+	return errors.Join(copyErr, file.Close())
+}
+
+func (r *Run) redactLog(contents []byte) ([]byte, error) {
+	return []byte(r.redactor.Redact(string(contents))), nil
+}
+
+// pruneExports removes the oldest exports of the group, by modification time,
+// so Retain remain. The files of an export share the name before the first dot.
+func (r *Run) pruneExports(export ArtifactExport) error {
+	if export.Retain <= 0 {
+		return nil
+	}
+
+	entries, err := afero.ReadDir(r.fs, export.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to read artifact export directory, %w", err)
+	}
+
+	exports := map[string][]fs.FileInfo{}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), export.Group+"-") {
+			continue
+		}
+
+		name, _, _ := strings.Cut(entry.Name(), ".")
+		exports[name] = append(exports[name], entry)
+	}
+
+	names := make([]string, 0, len(exports))
+	for name := range exports {
+		names = append(names, name)
+	}
+
+	newest := func(name string) int64 {
+		return slices.MaxFunc(exports[name], func(a, b fs.FileInfo) int {
+			return a.ModTime().Compare(b.ModTime())
+		}).ModTime().UnixNano()
+	}
+
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(cmp.Compare(newest(b), newest(a)), strings.Compare(b, a))
+	})
+
+	var errs []error
+
+	for _, name := range names[min(export.Retain, len(names)):] {
+		if name == export.Name {
+			continue
+		}
+
+		for _, file := range exports[name] {
+			if err := r.fs.Remove(filepath.Join(export.Dir, file.Name())); err != nil {
+				errs = append(errs, fmt.Errorf("failed to remove expired artifact export, %w", err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// WriteJUnitReport converts the playbook artifact into a JUnit report at path,
+// creating the parent directory when missing. The path is expected to be
+// outside the run directory, so the report outlives Cleanup. Sensitive values
+// are masked as in PlaybookArtifact, before the XML escapes them.
+func (r *Run) WriteJUnitReport(path string) error {
+	artifact, err := r.PlaybookArtifact()
+	if err != nil {
+		return err
+	}
+
+	report, err := ansible.JUnitReport(artifact)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := r.fs.MkdirAll(filepath.Dir(path), dirPermissions); err != nil {
+		return fmt.Errorf("failed to create JUnit report directory, %w", err)
+	}
+
+	if err := afero.WriteFile(r.fs, path, report, filePermissions); err != nil {
+		return fmt.Errorf("failed to write JUnit report, %w", err)
+	}
+
+	return nil
+}
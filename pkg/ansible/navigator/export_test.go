@@ -0,0 +1,281 @@
+package navigator
+
+import (
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+const testExportDir = "/exports"
+
+func newTestExportRun(t *testing.T) *Run {
+	t.Helper()
+
+	run, _ := newTestRun(t, false)
+
+	if err := run.fs.MkdirAll(testHostDir, dirPermissions); err != nil {
+		t.Fatalf("failed to create run directory: %v", err)
+	}
+
+	if err := afero.WriteFile(run.fs, run.hostJoin(playbookArtifactFilename), []byte(`{"status":"successful"}`), filePermissions); err != nil {
+		t.Fatalf("failed to write playbook artifact: %v", err)
+	}
+
+	if err := afero.WriteFile(run.fs, run.hostJoin(navigatorLogFilename), []byte("log\n"), filePermissions); err != nil {
+		t.Fatalf("failed to write navigator log: %v", err)
+	}
+
+	return run
+}
+
+func TestExportGzip(t *testing.T) {
+	t.Parallel()
+
+	run := newTestExportRun(t)
+
+	got, err := run.Export(ArtifactExport{Dir: testExportDir, Name: "id-1-create", Group: "id", Gzip: true})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	if want := testExportDir + "/id-1-create.playbook-artifact.json.gz"; got != want {
+		t.Fatalf("want path %s, got %s", want, got)
+	}
+
+	file, err := run.fs.Open(got)
+	if err != nil {
+		t.Fatalf("failed to open export: %v", err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("export is not gzipped: %v", err)
+	}
+
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+
+	if string(contents) != `{"status":"successful"}` {
+		t.Errorf("unexpected export contents %q", contents)
+	}
+
+	if _, err := run.fs.Stat(testExportDir + "/id-1-create.ansible-navigator.log.gz"); err != nil {
+		t.Errorf("navigator log not exported: %v", err)
+	}
+}
+
+func TestExportRedacted(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig(false)
+	config.RedactValues = []string{"p<ss\"word"}
+
+	run, _ := newTestRunWithConfig(t, config)
+	if err := run.fs.MkdirAll(testHostDir, dirPermissions); err != nil {
+		t.Fatalf("failed to create run directory: %v", err)
+	}
+
+	artifact := `{"status":"failed","stdout":["denied for p<ss\"word"],"plays":[]}`
+	if err := afero.WriteFile(run.fs, run.hostJoin(playbookArtifactFilename), []byte(artifact), filePermissions); err != nil {
+		t.Fatalf("failed to write playbook artifact: %v", err)
+	}
+
+	if err := afero.WriteFile(run.fs, run.hostJoin(navigatorLogFilename), []byte("vars: p<ss\"word\n"), filePermissions); err != nil {
+		t.Fatalf("failed to write navigator log: %v", err)
+	}
+
+	path, err := run.Export(ArtifactExport{Dir: testExportDir, Name: "id-1-create", Group: "id"})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	contents, err := afero.ReadFile(run.fs, path)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+
+	if want := `{"plays":[],"status":"failed","stdout":["denied for (redacted)"]}`; string(contents) != want {
+		t.Errorf("want export %q, got %q", want, contents)
+	}
+
+	log, err := afero.ReadFile(run.fs, testExportDir+"/id-1-create.ansible-navigator.log")
+	if err != nil {
+		t.Fatalf("failed to read exported navigator log: %v", err)
+	}
+
+	if want := "vars: (redacted)\n"; string(log) != want {
+		t.Errorf("want exported navigator log %q, got %q", want, log)
+	}
+}
+
+func TestExportRetain(t *testing.T) {
+	t.Parallel()
+
+	run := newTestExportRun(t)
+
+	if err := afero.WriteFile(run.fs, testExportDir+"/other-1-create.playbook-artifact.json", nil, filePermissions); err != nil {
+		t.Fatalf("failed to write export of another group: %v", err)
+	}
+
+	start := time.Now().Add(-time.Hour)
+
+	for number, name := range []string{"id-1-create", "id-2-update", "id-3-update"} {
+		if _, err := run.Export(ArtifactExport{Dir: testExportDir, Name: name, Group: "id", Retain: 2}); err != nil {
+			t.Fatalf("export %s failed: %v", name, err)
+		}
+
+		modTime := start.Add(time.Duration(number) * time.Minute)
+		for _, filename := range []string{playbookArtifactFilename, navigatorLogFilename} {
+			if err := run.fs.Chtimes(testExportDir+"/"+name+"."+filename, modTime, modTime); err != nil {
+				t.Fatalf("failed to set modification time: %v", err)
+			}
+		}
+	}
+
+	entries, err := afero.ReadDir(run.fs, testExportDir)
+	if err != nil {
+		t.Fatalf("failed to read export directory: %v", err)
+	}
+
+	got := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+
+	assertLines(t, "exports", got, []string{
+		"id-2-update.ansible-navigator.log",
+		"id-2-update.playbook-artifact.json",
+		"id-3-update.ansible-navigator.log",
+		"id-3-update.playbook-artifact.json",
+		"other-1-create.playbook-artifact.json",
+	})
+}
+
+// A destroy shares its run number with the create or update before it when
+// that run did not record its number, the operation keeps the names apart.
+func TestExportRetainMixedOperations(t *testing.T) {
+	t.Parallel()
+
+	run := newTestExportRun(t)
+
+	start := time.Now().Add(-time.Hour)
+
+	exports := []ArtifactExport{
+		{Dir: testExportDir, Name: "id-1-create", Group: "id", Gzip: true, Retain: 2},
+		{Dir: testExportDir, Name: "id-2-update", Group: "id", Retain: 2},
+		{Dir: testExportDir, Name: "id-2-delete", Group: "id", Gzip: true, Retain: 2},
+	}
+
+	for number, export := range exports {
+		if _, err := run.Export(export); err != nil {
+			t.Fatalf("export %s failed: %v", export.Name, err)
+		}
+
+		modTime := start.Add(time.Duration(number) * time.Minute)
+		for _, filename := range []string{playbookArtifactFilename, navigatorLogFilename} {
+			if err := run.fs.Chtimes(export.path(filename), modTime, modTime); err != nil {
+				t.Fatalf("failed to set modification time: %v", err)
+			}
+		}
+	}
+
+	entries, err := afero.ReadDir(run.fs, testExportDir)
+	if err != nil {
+		t.Fatalf("failed to read export directory: %v", err)
+	}
+
+	got := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+
+	assertLines(t, "exports", got, []string{
+		"id-2-delete.ansible-navigator.log.gz",
+		"id-2-delete.playbook-artifact.json.gz",
+		"id-2-update.ansible-navigator.log",
+		"id-2-update.playbook-artifact.json",
+	})
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	t.Parallel()
+
+	run := newTestExportRun(t)
+	path := testExportDir + "/reports/junit.xml"
+
+	if err := run.WriteJUnitReport(path); err != nil {
+		t.Fatalf("write JUnit report failed: %v", err)
+	}
+
+	// The report is outside the run directory.
+	if err := run.Cleanup(); err != nil {
+		t.Fatalf("cleanup failed: %v", err)
+	}
+
+	contents, err := afero.ReadFile(run.fs, path)
+	if err != nil {
+		t.Fatalf("failed to read JUnit report: %v", err)
+	}
+
+	if want := `<testsuites name="ansible-playbook" tests="0"`; !strings.Contains(string(contents), want) {
+		t.Errorf("want report containing %q, got:\n%s", want, contents)
+	}
+}
+
+// XML escapes the secret, so it is masked in the artifact rather than the
+// report.
+func TestWriteJUnitReportRedacted(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig(false)
+	config.RedactValues = []string{"p<ss&word"}
+
+	run, _ := newTestRunWithConfig(t, config)
+	if err := run.fs.MkdirAll(testHostDir, dirPermissions); err != nil {
+		t.Fatalf("failed to create run directory: %v", err)
+	}
+
+	artifact := `{"status":"failed","plays":[{"name":"Test","tasks":[
+		{"task":"Login as p<ss&word","task_action":"ansible.builtin.uri","host":"a","res":{"failed":true,"msg":"denied for p<ss&word"}}
+	]}]}`
+	if err := afero.WriteFile(run.fs, run.hostJoin(playbookArtifactFilename), []byte(artifact), filePermissions); err != nil {
+		t.Fatalf("failed to write playbook artifact: %v", err)
+	}
+
+	path := testExportDir + "/junit.xml"
+	if err := run.WriteJUnitReport(path); err != nil {
+		t.Fatalf("write JUnit report failed: %v", err)
+	}
+
+	contents, err := afero.ReadFile(run.fs, path)
+	if err != nil {
+		t.Fatalf("failed to read JUnit report: %v", err)
+	}
+
+	if strings.Contains(string(contents), "ss&amp;word") {
+		t.Errorf("want secret masked, got:\n%s", contents)
+	}
+
+	for _, want := range []string{`name="Login as (redacted)"`, `message="denied for (redacted)"`} {
+		if !strings.Contains(string(contents), want) {
+			t.Errorf("want report containing %q, got:\n%s", want, contents)
+		}
+	}
+}
+
+func TestWriteJUnitReportNoArtifact(t *testing.T) {
+	t.Parallel()
+
+	run, _ := newTestRun(t, false)
+
+	if err := run.WriteJUnitReport(testExportDir + "/junit.xml"); err == nil {
+		t.Fatal("want error without a playbook artifact, got none")
+	}
+}
@@ -76,6 +76,16 @@ func (e *fakeExecutor) Run(_ context.Context, command ansible.Command) ([]byte,
 	return nil, nil
 }
 
+func (e *fakeExecutor) Stream(ctx context.Context, command ansible.Command, handler ansible.OutputHandler) ([]byte, error) {
+	output, err := e.Run(ctx, command)
+
+	for line := range strings.Lines(string(output)) {
+		handler(strings.TrimSuffix(line, "\n"))
+	}
+
+	return output, err
+}
+
 func (e *fakeExecutor) commandStrings() []string {
 	strs := make([]string, 0, len(e.commands))
 	for _, command := range e.commands {
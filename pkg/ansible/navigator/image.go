@@ -0,0 +1,101 @@
+package navigator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+)
+
+// imageRepoDigestsFormat lists the repository digests of the image one per
+// line, such as 'registry/name@sha256:...'. Docker and Podman both accept it.
+const imageRepoDigestsFormat = "{{range .RepoDigests}}{{println .}}{{end}}"
+
+var (
+	ErrImageInspect  = errors.New("container image digest not resolved")
+	ErrImageNoDigest = errors.New("container image has no repository digest")
+)
+
+// ImageIsPinned reports whether the image name includes a digest, such as
+// 'image@sha256:...', rather than only a tag that may move.
+func ImageIsPinned(image string) bool {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return false
+	}
+
+	_, ok := named.(reference.Digested)
+
+	return ok
+}
+
+// resolveContainerEngine picks the first container engine found in PATH for
+// auto, and returns auto when there is none.
+func resolveContainerEngine(exec ansible.Executor, engine ContainerEngine) ContainerEngine {
+	if engine != ContainerEngineAuto {
+		return engine
+	}
+
+	for _, option := range containerEnginePrograms() {
+		if _, err := exec.LookPath(option.String()); err == nil {
+			return option
+		}
+	}
+
+	return ContainerEngineAuto
+}
+
+// InspectImageDigest returns the repository digest of the image in the local
+// store of the container engine, which changes whenever a tag is pulled again
+// and points to a different image. Of several repository digests the one of
+// the image's own repository is preferred. An image without any, such as one
+// built locally, has no digest to return.
+func InspectImageDigest(ctx context.Context, exec ansible.Executor, engine ContainerEngine, image string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", fmt.Errorf("%w, %w", ErrImageInspect, err)
+	}
+
+	engine = resolveContainerEngine(exec, engine)
+	if engine == ContainerEngineAuto {
+		return "", fmt.Errorf("%w, no container engine found in PATH", ErrImageInspect)
+	}
+
+	stdoutStderr, err := exec.Run(ctx, ansible.Command{Name: engine.String(), Args: []string{"image", "inspect", "--format", imageRepoDigestsFormat, image}})
+	if err != nil {
+		return "", fmt.Errorf("%w, '%s image inspect' command failed, %w", ErrImageInspect, engine, err)
+	}
+
+	var digests []string
+
+	for line := range strings.Lines(string(stdoutStderr)) {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		repoDigest, err := reference.ParseNormalizedNamed(line)
+		if err != nil {
+			return "", fmt.Errorf("%w, '%s image inspect' command output not expected, %w", ErrImageInspect, engine, err)
+		}
+
+		canonical, ok := repoDigest.(reference.Canonical)
+		if !ok {
+			return "", fmt.Errorf("%w, '%s image inspect' command output not expected", ErrImageInspect, engine)
+		}
+
+		if canonical.Name() == named.Name() {
+			return canonical.Digest().String(), nil
+		}
+
+		digests = append(digests, canonical.Digest().String())
+	}
+
+	if len(digests) == 0 {
+		return "", fmt.Errorf("%w, %w, such as when built locally", ErrImageInspect, ErrImageNoDigest)
+	}
+
+	return digests[0], nil
+}
@@ -5,6 +5,8 @@ type Mode int
 const (
 	ModeHost Mode = iota
 	ModeEE
+	// ModeRunner invokes ansible-runner directly, for hosts without navigator.
+	ModeRunner
 )
 
 func (m Mode) String() string {
@@ -13,6 +15,8 @@ func (m Mode) String() string {
 		return "host"
 	case ModeEE:
 		return "ee"
+	case ModeRunner:
+		return "runner"
 	}
 
 	return "unknown"
@@ -23,6 +27,10 @@ func (m Mode) UsesEE() bool {
 }
 
 func (c RunConfig) mode() Mode {
+	if c.UseRunner {
+		return ModeRunner
+	}
+
 	if c.Settings.ExecutionEnvironment.Enabled {
 		return ModeEE
 	}
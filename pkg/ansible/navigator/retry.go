@@ -0,0 +1,136 @@
+package navigator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+)
+
+type RetryOn string
+
+type RetryOns []RetryOn
+
+const (
+	RetryOnFailed      RetryOn = "failed"
+	RetryOnUnreachable RetryOn = "unreachable"
+)
+
+func (o RetryOn) String() string {
+	return string(o)
+}
+
+func (o RetryOns) Strings() []string {
+	output := make([]string, 0, len(o))
+	for _, element := range o {
+		output = append(output, element.String())
+	}
+
+	return output
+}
+
+func AllRetryOns() RetryOns {
+	return RetryOns{RetryOnFailed, RetryOnUnreachable}
+}
+
+// RetryPolicy is the zero value for a single attempt. Backoff doubles after
+// each attempt, up to MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	RetryOn        RetryOns
+	// UnreachableHostsOnly limits each retry to the hosts of the previous
+	// attempt whose problem is retried: unreachable hosts, and failed hosts
+	// when RetryOn includes failed.
+	UnreachableHostsOnly bool
+}
+
+type Attempt struct {
+	Number           int
+	Status           ansible.Status
+	Limit            []string
+	FailedHosts      []string
+	UnreachableHosts []string
+	Err              error
+}
+
+// Outcome is how the retry policy sees the attempt. An attempt is only
+// unreachable when every problem host is unreachable, a failed host makes
+// the attempt failed.
+func (a Attempt) Outcome() RetryOn {
+	if len(a.UnreachableHosts) > 0 && len(a.FailedHosts) == 0 {
+		return RetryOnUnreachable
+	}
+
+	return RetryOnFailed
+}
+
+func (a Attempt) String() string {
+	if a.Err == nil {
+		return fmt.Sprintf("attempt %d: %s", a.Number, ansible.StatusSuccessful)
+	}
+
+	summary := fmt.Sprintf("attempt %d: %s", a.Number, a.Outcome())
+	if a.Status == ansible.StatusTimeout || a.Status == ansible.StatusCanceled {
+		summary = fmt.Sprintf("attempt %d: %s", a.Number, a.Status)
+	}
+
+	if len(a.Limit) > 0 {
+		summary += fmt.Sprintf(", limited to %s", strings.Join(a.Limit, ","))
+	}
+
+	if len(a.FailedHosts) > 0 {
+		summary += fmt.Sprintf(", failed hosts %s", strings.Join(a.FailedHosts, ","))
+	}
+
+	if len(a.UnreachableHosts) > 0 {
+		summary += fmt.Sprintf(", unreachable hosts %s", strings.Join(a.UnreachableHosts, ","))
+	}
+
+	return summary
+}
+
+func (p RetryPolicy) retries(attempt Attempt) bool {
+	if attempt.Number >= p.MaxAttempts || attempt.Status == ansible.StatusTimeout || attempt.Status == ansible.StatusCanceled {
+		return false
+	}
+
+	if p.UnreachableHostsOnly && len(p.limit(attempt)) == 0 {
+		return false
+	}
+
+	return slices.Contains(p.RetryOn, attempt.Outcome())
+}
+
+// limit is the hosts of the attempt whose problem the policy retries.
+func (p RetryPolicy) limit(attempt Attempt) []string {
+	var hosts []string
+
+	if slices.Contains(p.RetryOn, RetryOnFailed) {
+		hosts = append(hosts, attempt.FailedHosts...)
+	}
+
+	if slices.Contains(p.RetryOn, RetryOnUnreachable) {
+		hosts = append(hosts, attempt.UnreachableHosts...)
+	}
+
+	slices.Sort(hosts)
+
+	return slices.Compact(hosts)
+}
+
+func (p RetryPolicy) backoff(attempt Attempt) time.Duration {
+	backoff := p.InitialBackoff
+	for range attempt.Number - 1 {
+		if backoff >= p.MaxBackoff {
+			break
+		}
+
+		backoff *= 2
+	}
+
+	return min(backoff, p.MaxBackoff)
+}
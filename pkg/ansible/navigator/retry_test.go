@@ -0,0 +1,325 @@
+package navigator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+	"github.com/spf13/afero"
+)
+
+var errTestPlaybook = errors.New("exit status 2")
+
+// attemptExecutor writes the playbook artifact of the next recap on each
+// playbook run, the way navigator would. A failed run returns err when set.
+type attemptExecutor struct {
+	*fakeExecutor
+
+	fs     afero.Fs
+	path   string
+	recaps []string
+	err    error
+}
+
+func (e *attemptExecutor) Run(ctx context.Context, command ansible.Command) ([]byte, error) {
+	if !strings.Contains(command.String(), "run "+testHostDir) {
+		return e.fakeExecutor.Run(ctx, command)
+	}
+
+	e.commands = append(e.commands, command)
+
+	recap := e.recaps[0]
+	e.recaps = e.recaps[1:]
+
+	status := ansible.StatusFailed
+	if !strings.Contains(recap, "failed=1") && !strings.Contains(recap, "unreachable=1") {
+		status = ansible.StatusSuccessful
+	}
+
+	stdout, err := json.Marshal(append([]string{"PLAY RECAP ***"}, strings.Split(recap, "\n")...))
+	if err != nil {
+		return nil, err
+	}
+
+	artifact := fmt.Sprintf(`{"status":%q,"stdout":%s,"plays":[]}`, status, stdout)
+	if err := afero.WriteFile(e.fs, e.path, []byte(artifact), filePermissions); err != nil {
+		return nil, err
+	}
+
+	if status == ansible.StatusFailed {
+		if e.err != nil {
+			return []byte(recap), e.err
+		}
+
+		return []byte(recap), errTestPlaybook
+	}
+
+	return []byte(recap), nil
+}
+
+func newTestAttemptRun(t *testing.T, retry RetryPolicy, recaps ...string) (*Run, *attemptExecutor) {
+	t.Helper()
+
+	config := testConfig(false)
+	config.Retry = retry
+
+	memFs := afero.NewMemMapFs()
+	exec := &attemptExecutor{fakeExecutor: newFakeExecutor(), fs: memFs, recaps: recaps}
+	run := NewRun(testHostDir, config, WithFs(memFs), WithExecutor(exec))
+	exec.path = run.hostJoin(playbookArtifactFilename)
+
+	if err := memFs.MkdirAll(testHostDir, dirPermissions); err != nil {
+		t.Fatalf("failed to create run directory: %v", err)
+	}
+
+	return run, exec
+}
+
+const (
+	testRecapOK          = "a : ok=1 changed=0 unreachable=0 failed=0"
+	testRecapFailed      = "a : ok=0 changed=0 unreachable=0 failed=1"
+	testRecapUnreachable = "a : ok=0 changed=0 unreachable=1 failed=0"
+	testRecapMixed       = testRecapUnreachable + "\nb : ok=0 changed=0 unreachable=0 failed=1"
+)
+
+func TestExecuteRetries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		retry    RetryPolicy
+		recaps   []string
+		wantErr  bool
+		attempts []string
+	}{
+		{
+			name:     "no_policy",
+			recaps:   []string{testRecapUnreachable},
+			wantErr:  true,
+			attempts: []string{"attempt 1: unreachable, limited to host1,host2, unreachable hosts a"},
+		},
+		{
+			name:   "unreachable_then_ok",
+			retry:  RetryPolicy{MaxAttempts: 3, RetryOn: RetryOns{RetryOnUnreachable}},
+			recaps: []string{testRecapUnreachable, testRecapOK},
+			attempts: []string{
+				"attempt 1: unreachable, limited to host1,host2, unreachable hosts a",
+				"attempt 2: successful",
+			},
+		},
+		{
+			name:     "failed_not_retried",
+			retry:    RetryPolicy{MaxAttempts: 3, RetryOn: RetryOns{RetryOnUnreachable}},
+			recaps:   []string{testRecapFailed},
+			wantErr:  true,
+			attempts: []string{"attempt 1: failed, limited to host1,host2, failed hosts a"},
+		},
+		{
+			name:     "mixed_not_retried_on_unreachable",
+			retry:    RetryPolicy{MaxAttempts: 3, RetryOn: RetryOns{RetryOnUnreachable}},
+			recaps:   []string{testRecapMixed},
+			wantErr:  true,
+			attempts: []string{"attempt 1: failed, limited to host1,host2, failed hosts b, unreachable hosts a"},
+		},
+		{
+			name:    "max_attempts",
+			retry:   RetryPolicy{MaxAttempts: 2, RetryOn: AllRetryOns()},
+			recaps:  []string{testRecapFailed, testRecapFailed},
+			wantErr: true,
+			attempts: []string{
+				"attempt 1: failed, limited to host1,host2, failed hosts a",
+				"attempt 2: failed, limited to host1,host2, failed hosts a",
+			},
+		},
+		{
+			name:   "unreachable_hosts_only",
+			retry:  RetryPolicy{MaxAttempts: 3, RetryOn: RetryOns{RetryOnUnreachable}, UnreachableHostsOnly: true},
+			recaps: []string{testRecapUnreachable, testRecapOK},
+			attempts: []string{
+				"attempt 1: unreachable, limited to host1,host2, unreachable hosts a",
+				"attempt 2: successful",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			run, _ := newTestAttemptRun(t, test.retry, test.recaps...)
+
+			err := run.Execute(context.Background())
+			if (err != nil) != test.wantErr {
+				t.Fatalf("want error %t, got %v", test.wantErr, err)
+			}
+
+			got := make([]string, 0, len(run.Attempts))
+			for _, attempt := range run.Attempts {
+				got = append(got, attempt.String())
+			}
+
+			assertLines(t, "attempts", got, test.attempts)
+		})
+	}
+}
+
+func TestExecuteRetryLimitsUnreachableHosts(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		retryOn   RetryOns
+		recap     string
+		wantLimit string
+	}{
+		"unreachable": {
+			retryOn:   RetryOns{RetryOnUnreachable},
+			recap:     testRecapUnreachable,
+			wantLimit: "a",
+		},
+		"mixed_failed_and_unreachable": {
+			retryOn:   AllRetryOns(),
+			recap:     testRecapMixed,
+			wantLimit: "a,b",
+		},
+		"mixed_failed_only": {
+			retryOn:   RetryOns{RetryOnFailed},
+			recap:     testRecapMixed,
+			wantLimit: "b",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			retry := RetryPolicy{MaxAttempts: 2, RetryOn: test.retryOn, UnreachableHostsOnly: true}
+			run, exec := newTestAttemptRun(t, retry, test.recap, testRecapOK)
+
+			if err := run.Execute(context.Background()); err != nil {
+				t.Fatalf("execute failed: %v", err)
+			}
+
+			commands := exec.commandStrings()
+			if len(commands) != 2 {
+				t.Fatalf("want 2 commands, got %d", len(commands))
+			}
+
+			if !strings.Contains(commands[0], "--limit host1,host2") || !strings.Contains(commands[1], "--limit "+test.wantLimit+" ") {
+				t.Errorf("unexpected limits:\n%s", strings.Join(commands, "\n"))
+			}
+
+			artifact, err := run.PlaybookArtifact()
+			if err != nil {
+				t.Fatalf("failed to read playbook artifact: %v", err)
+			}
+
+			if artifact.Status != ansible.StatusSuccessful {
+				t.Errorf("want artifact of the last attempt, got status %s", artifact.Status)
+			}
+		})
+	}
+}
+
+func TestExecuteRetryRespectsDeadline(t *testing.T) {
+	t.Parallel()
+
+	retry := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour, MaxBackoff: time.Hour, RetryOn: AllRetryOns()}
+	run, _ := newTestAttemptRun(t, retry, testRecapFailed, testRecapOK)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	err := run.Execute(ctx)
+	if err == nil || !strings.Contains(err.Error(), "exceeds the time remaining") {
+		t.Fatalf("want deadline error, got %v", err)
+	}
+
+	if len(run.Attempts) != 1 {
+		t.Errorf("want 1 attempt, got %d", len(run.Attempts))
+	}
+}
+
+func TestExecuteInterrupted(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		ctx        func() (context.Context, context.CancelFunc)
+		err        error
+		wantStatus ansible.Status
+	}{
+		"canceled": {
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx, cancel
+			},
+			wantStatus: ansible.StatusCanceled,
+		},
+		"timeout": {
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithDeadline(context.Background(), time.Now())
+			},
+			wantStatus: ansible.StatusTimeout,
+		},
+		// The command is interrupted a grace period ahead of the deadline.
+		"interrupted_before_deadline": {
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Minute)
+			},
+			err:        fmt.Errorf("%w, %w", context.DeadlineExceeded, errTestPlaybook),
+			wantStatus: ansible.StatusTimeout,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			retry := RetryPolicy{MaxAttempts: 3, RetryOn: AllRetryOns()}
+			run, exec := newTestAttemptRun(t, retry, testRecapFailed, testRecapOK)
+			exec.err = test.err
+
+			ctx, cancel := test.ctx()
+			defer cancel()
+
+			if err := run.Execute(ctx); err == nil {
+				t.Fatal("want error, got none")
+			}
+
+			if run.Status != test.wantStatus {
+				t.Errorf("want status %s, got %s", test.wantStatus, run.Status)
+			}
+
+			// The partial results are kept.
+			if !strings.Contains(run.Output, testRecapFailed) {
+				t.Errorf("want output of the playbook artifact, got %q", run.Output)
+			}
+
+			got := make([]string, 0, len(run.Attempts))
+			for _, attempt := range run.Attempts {
+				got = append(got, attempt.String())
+			}
+
+			assertLines(t, "attempts", got, []string{fmt.Sprintf("attempt 1: %s, limited to host1,host2, failed hosts a", test.wantStatus)})
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{InitialBackoff: 5 * time.Second, MaxBackoff: 30 * time.Second}
+
+	var got []string
+	for number := 1; number <= 4; number++ {
+		got = append(got, policy.backoff(Attempt{Number: number}).String())
+	}
+
+	assertLines(t, "backoffs", got, []string{"5s", "10s", "20s", "30s"})
+}
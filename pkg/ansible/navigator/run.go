@@ -3,7 +3,10 @@ package navigator
 import (
 	"fmt"
 	"maps"
+	"path"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
 	"github.com/spf13/afero"
@@ -18,34 +21,112 @@ const (
 	dirPermissions            = 0o700
 	filePermissions           = 0o600
 
-	containerRunDir = "/tmp/run"
+	containerRunDir                = "/tmp/run"
+	containerRequirementsSourceDir = "/tmp/requirements-source"
 
 	inventoriesDir   = "inventories"
 	extraVarsDir     = "extra-vars"
 	privateKeysDir   = "private-keys"
+	vaultPasswordDir = "vault-passwords"
 	knownHostsDir    = "known-hosts"
 	knownHostsFile   = "known_hosts"
+	sshAgentSocket   = "ssh-agent.sock"
+	sshProxyDir      = "ssh-proxy"
+	sshProxyConfig   = "ssh_config"
 	playbookFilename = "playbook.yaml"
+	projectDir       = "project"
+
+	runnerEnvDir       = "env"
+	runnerArtifactsDir = "artifacts"
+	runnerIdent        = "run"
+
+	requirementsFilename = "requirements.yml"
+	collectionsDir       = "collections"
+	rolesDir             = "roles"
 )
 
 type RunConfig struct {
 	WorkingDir      string
 	Binary          string
+	UseRunner       bool
+	RunnerBinary    string
 	Playbook        string
 	Inventories     []ansible.Inventory
 	ExtraVars       []ansible.ExtraVarsFile
 	PrivateKeys     []ansible.PrivateKey
+	UseSSHAgent     bool
+	VaultPasswords  []ansible.VaultPassword
+	Requirements    ansible.Requirements
+	Files           []ansible.ProjectFile
 	KnownHosts      []ansible.KnownHost
 	UseKnownHosts   bool
+	SSHProxy        ansible.SSHProxy
 	HostKeyChecking bool
 	Options         ansible.PlaybookOptions
 	Settings        Settings
+	Retry           RetryPolicy
+	// CancelGracePeriod is how long the run has to save its results once
+	// interrupted, before it is killed. It is taken out of the time remaining
+	// before the deadline of the context.
+	CancelGracePeriod time.Duration
+	// RedactValues are masked along with the sensitive values of the config.
+	RedactValues []string
+}
+
+// sensitiveValues are masked in output, so they do not end up in logs. Set
+// environment variables count when their name suggests a secret.
+func (c RunConfig) sensitiveValues() []string {
+	values := slices.Clone(c.RedactValues)
+
+	for _, key := range c.PrivateKeys {
+		values = append(values, key.Data, key.Passphrase)
+	}
+
+	for _, password := range c.VaultPasswords {
+		values = append(values, password.Password)
+	}
+
+	for name, value := range c.Settings.ExecutionEnvironment.EnvironmentVariables.Set {
+		if ansible.IsSensitiveEnvVarName(name) {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// usesKnownHosts is also true with a proxy, as its host key is checked against
+// the same file.
+func (c RunConfig) usesKnownHosts() bool {
+	return c.UseKnownHosts || !c.SSHProxy.IsEmpty()
+}
+
+// usesSSHAgent reports whether the key is served by an SSH agent rather than
+// written to the run directory. An encrypted key always is, as it must never
+// be written to disk decrypted.
+func (c RunConfig) usesSSHAgent(key ansible.PrivateKey) bool {
+	return c.UseSSHAgent || key.Passphrase != ""
+}
+
+func (c RunConfig) sshAgentKeys() []ansible.PrivateKey {
+	var keys []ansible.PrivateKey
+
+	for _, key := range c.PrivateKeys {
+		if c.usesSSHAgent(key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
 }
 
 // Zero until Preflight has run.
 type preflightResults struct {
-	navigatorBinary string
-	workingDir      string
+	navigatorBinary    string
+	runnerBinary       string
+	workingDir         string
+	requirementsSource string
+	containerEngine    ContainerEngine
 }
 
 type Run struct {
@@ -57,10 +138,19 @@ type Run struct {
 	dirs             runDirs
 	resolved         preflightResults
 	artifactContents []byte
+	outputHandler    ansible.OutputHandler
+	redactor         *ansible.Redactor
+	sshAgent         *ansible.SSHAgent
 
 	Command ansible.Command
 	Output  string
 	Status  ansible.Status
+	// ImageDigest is the repository digest of the execution environment image,
+	// empty when not known. Set by Preflight and again after each attempt, as the
+	// image may be pulled during the run.
+	ImageDigest string
+	// Attempts made by the last Execute, in order.
+	Attempts []Attempt
 }
 
 type RunOption func(*Run)
@@ -77,12 +167,21 @@ func WithExecutor(exec ansible.Executor) RunOption {
 	}
 }
 
+// WithOutputHandler streams navigator output line by line while the playbook
+// runs. Ignored when the executor cannot stream.
+func WithOutputHandler(handler ansible.OutputHandler) RunOption {
+	return func(r *Run) {
+		r.outputHandler = handler
+	}
+}
+
 func NewRun(hostDir string, config RunConfig, opts ...RunOption) *Run {
 	run := &Run{
-		fs:     afero.NewOsFs(),
-		exec:   ansible.OSExecutor(),
-		config: config,
-		dirs:   newRunDirs(hostDir, config.mode()),
+		fs:       afero.NewOsFs(),
+		exec:     ansible.OSExecutor(),
+		config:   config,
+		dirs:     newRunDirs(hostDir, config.mode()),
+		redactor: ansible.NewRedactor(config.sensitiveValues()...),
 	}
 	for _, opt := range opts {
 		opt(run)
@@ -91,6 +190,18 @@ func NewRun(hostDir string, config RunConfig, opts ...RunOption) *Run {
 	return run
 }
 
+// Redact masks the sensitive values of the run config. Output, streamed lines,
+// the parsed playbook artifact and query results are already masked.
+func (r *Run) Redact(s string) string {
+	return r.redactor.Redact(s)
+}
+
+// ShortSensitiveValues counts the sensitive values of the run config that are
+// too short to be masked.
+func (r *Run) ShortSensitiveValues() int {
+	return ansible.ShortValues(r.config.sensitiveValues()...)
+}
+
 func (r *Run) Mode() Mode {
 	return r.config.mode()
 }
@@ -121,7 +232,24 @@ func (r *Run) SetEnv(name string, value string) {
 	r.env[name] = value
 }
 
+// Close stops the SSH agent started by Setup, if any, and leaves the run
+// directory in place.
+func (r *Run) Close() error {
+	if r.sshAgent == nil {
+		return nil
+	}
+
+	err := r.sshAgent.Close()
+	r.sshAgent = nil
+
+	return err
+}
+
 func (r *Run) Cleanup() error {
+	if err := r.Close(); err != nil {
+		return err
+	}
+
 	if err := r.fs.RemoveAll(r.HostDir()); err != nil {
 		return fmt.Errorf("failed to remove run directory, %w", err)
 	}
@@ -136,7 +264,7 @@ func (r *Run) settings() Settings {
 	execEnv.EnvironmentVariables.Pass = slices.Clone(execEnv.EnvironmentVariables.Pass)
 	execEnv.EnvironmentVariables.Set = maps.Clone(execEnv.EnvironmentVariables.Set)
 
-	for _, name := range slices.Sorted(maps.Keys(r.env)) {
+	for _, name := range slices.Sorted(maps.Keys(r.environment())) {
 		execEnv.EnvironmentVariables.pass(name)
 	}
 
@@ -146,11 +274,62 @@ func (r *Run) settings() Settings {
 			Dest:    r.PlaybookDir(),
 			Options: VolumeMountOptions{VolumeMountRelabelUnshared},
 		})
+
+		if r.resolved.requirementsSource != "" {
+			execEnv.VolumeMounts = append(execEnv.VolumeMounts, VolumeMount{
+				Src:     r.resolved.requirementsSource,
+				Dest:    r.requirementsSourcePath(),
+				Options: VolumeMountOptions{VolumeMountReadOnly},
+			})
+		}
 	}
 
 	return settings
 }
 
+// environment is the env set with SetEnv, plus the search paths for installed
+// requirements. The defaults stay on the search paths, because the variables
+// take precedence over any paths configured in ansible.cfg.
+func (r *Run) environment() map[string]string {
+	env := maps.Clone(r.env)
+	if env == nil {
+		env = map[string]string{}
+	}
+
+	// Navigator mounts the agent socket into the execution environment.
+	if len(r.config.sshAgentKeys()) > 0 {
+		env[ansible.SSHAuthSockEnvVar] = r.hostJoin(sshAgentSocket)
+	}
+
+	if r.config.Requirements.IsEmpty() {
+		return env
+	}
+
+	searchPaths := map[string][]string{
+		ansible.CollectionsPathEnvVar: {r.playbookJoin(collectionsDir), "~/.ansible/collections", "/usr/share/ansible/collections"},
+		ansible.RolesPathEnvVar:       {r.playbookJoin(rolesDir), "~/.ansible/roles", "/usr/share/ansible/roles", "/etc/ansible/roles"},
+	}
+
+	for name, paths := range searchPaths {
+		if _, ok := env[name]; !ok {
+			env[name] = strings.Join(paths, ":")
+		}
+	}
+
+	return env
+}
+
+// playbookFile is relative to the run directory. With project files the
+// playbook moves into the project directory, so roles, templates and vars
+// files resolve relative to it.
+func (r *Run) playbookFile() string {
+	if len(r.config.Files) > 0 {
+		return path.Join(projectDir, playbookFilename)
+	}
+
+	return playbookFilename
+}
+
 func (r *Run) hostJoin(parts ...string) string {
 	return r.dirs.host.join(parts...)
 }
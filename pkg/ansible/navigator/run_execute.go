@@ -2,35 +2,241 @@ package navigator
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
 	"github.com/spf13/afero"
 )
 
+// Execute runs the playbook, retrying as the policy allows. A retry is skipped
+// when its backoff would run past the context deadline.
 func (r *Run) Execute(ctx context.Context) error {
-	r.Command = r.navigatorCommand()
+	r.Attempts = nil
+
+	for number := 1; ; number++ {
+		err := r.executeAttempt(ctx)
+
+		attempt := Attempt{
+			Number: number,
+			Status: r.Status,
+			Limit:  slices.Clone(r.config.Options.Limit),
+			Err:    err,
+		}
+
+		if err != nil {
+			attempt.FailedHosts, attempt.UnreachableHosts = r.problemHosts()
+		}
+
+		r.Attempts = append(r.Attempts, attempt)
+
+		if err == nil || !r.config.Retry.retries(attempt) {
+			return err
+		}
+
+		backoff := r.config.Retry.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Now().Add(backoff).After(deadline) {
+			return fmt.Errorf("%w, not retried as the backoff of %s exceeds the time remaining", err, backoff)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w, not retried, %w", err, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		if err := r.resetResults(); err != nil {
+			return err
+		}
+
+		if r.config.Retry.UnreachableHostsOnly {
+			r.config.Options.Limit = r.config.Retry.limit(attempt)
+
+			if r.config.mode() == ModeRunner {
+				if err := r.writeRunnerEnv(); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (r *Run) executeAttempt(ctx context.Context) error {
+	r.Command = r.command()
+
+	commandOutput, err := r.runCommand(ctx, r.Command)
+
+	r.refreshImageDigest(ctx)
+
+	if r.config.mode() == ModeRunner {
+		if artifactErr := r.writeRunnerArtifact(); artifactErr != nil && err == nil {
+			r.Output = r.redactor.Redact(string(commandOutput))
+
+			return artifactErr
+		}
+	}
 
-	commandOutput, err := r.exec.Run(ctx, r.Command)
 	if err != nil {
 		if artifact, readErr := r.playbookArtifact(); readErr == nil {
-			r.Output = artifact.Stdout.String()
+			r.Output = r.redactor.Redact(artifact.Stdout.String())
 			r.Status = artifact.Status
 		}
 
 		if r.Output == "" {
-			r.Output = string(commandOutput)
+			r.Output = r.redactor.Redact(string(commandOutput))
+		}
+
+		// An interrupted run records at most that it failed, if anything. The
+		// command is interrupted ahead of the deadline, so it may have exited
+		// before ctx is done.
+		switch {
+		case ctx.Err() != nil:
+			r.Status = contextStatus(ctx.Err())
+		case errors.Is(err, context.DeadlineExceeded):
+			r.Status = ansible.StatusTimeout
 		}
 
 		return fmt.Errorf("%s run command failed, %w", Program, err)
 	}
 
-	r.Output = string(commandOutput)
+	r.Output = r.redactor.Redact(string(commandOutput))
 	r.Status = ansible.StatusSuccessful
 
 	return nil
 }
 
+// refreshImageDigest keeps the digest from preflight when the image cannot be
+// inspected, such as after the run was interrupted.
+func (r *Run) refreshImageDigest(ctx context.Context) {
+	if !r.config.mode().UsesEE() || r.resolved.containerEngine == "" || r.config.Settings.ExecutionEnvironment.Pull.Policy == PullPolicyNever {
+		return
+	}
+
+	if digest, err := InspectImageDigest(ctx, r.exec, r.resolved.containerEngine, r.config.Settings.ExecutionEnvironment.Image); err == nil {
+		r.ImageDigest = digest
+	}
+}
+
+func contextStatus(err error) ansible.Status {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ansible.StatusTimeout
+	}
+
+	return ansible.StatusCanceled
+}
+
+// resetResults removes the results of the previous attempt, so each attempt
+// has a fresh artifact.
+func (r *Run) resetResults() error {
+	r.artifactContents = nil
+	r.Output = ""
+	r.Status = ""
+
+	if err := r.fs.Remove(r.hostJoin(playbookArtifactFilename)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove playbook artifact of previous attempt, %w", err)
+	}
+
+	if r.config.mode() == ModeRunner {
+		if err := r.fs.RemoveAll(r.hostJoin(runnerArtifactsDir, runnerIdent)); err != nil {
+			return fmt.Errorf("failed to remove %s artifacts of previous attempt, %w", ansible.RunnerProgram, err)
+		}
+	}
+
+	return nil
+}
+
+// problemHosts returns the failed and the unreachable hosts of the last
+// attempt. A host can be both.
+func (r *Run) problemHosts() ([]string, []string) {
+	artifact, err := r.playbookArtifact()
+	if err != nil {
+		return nil, nil
+	}
+
+	var failed, unreachable []string
+
+	for _, host := range slices.Sorted(maps.Keys(artifact.HostStats)) {
+		if artifact.HostStats[host].Failed > 0 {
+			failed = append(failed, host)
+		}
+
+		if artifact.HostStats[host].Unreachable > 0 {
+			unreachable = append(unreachable, host)
+		}
+	}
+
+	return failed, unreachable
+}
+
+func (r *Run) runCommand(ctx context.Context, command ansible.Command) ([]byte, error) {
+	if r.outputHandler != nil {
+		if streaming, ok := r.exec.(ansible.StreamingExecutor); ok {
+			handler := func(line string) {
+				r.outputHandler(r.redactor.Redact(line))
+			}
+
+			return streaming.Stream(ctx, command, handler) //nolint:wrapcheck
+		}
+	}
+
+	return r.exec.Run(ctx, command) //nolint:wrapcheck
+}
+
+// writeRunnerArtifact saves the job events of an ansible-runner run as a
+// playbook artifact, where the rest of the run expects to find it.
+func (r *Run) writeRunnerArtifact() error {
+	status, err := afero.ReadFile(r.fs, r.hostJoin(runnerArtifactsDir, runnerIdent, "status"))
+	if err != nil {
+		return fmt.Errorf("failed to read %s status, %w", ansible.RunnerProgram, err)
+	}
+
+	stdout, err := afero.ReadFile(r.fs, r.hostJoin(runnerArtifactsDir, runnerIdent, "stdout"))
+	if err != nil {
+		return fmt.Errorf("failed to read %s stdout, %w", ansible.RunnerProgram, err)
+	}
+
+	eventFiles, err := afero.ReadDir(r.fs, r.hostJoin(runnerArtifactsDir, runnerIdent, "job_events"))
+	if err != nil {
+		return fmt.Errorf("failed to read %s job events, %w", ansible.RunnerProgram, err)
+	}
+
+	events := make([]ansible.RunnerEvent, 0, len(eventFiles))
+	for _, eventFile := range eventFiles {
+		if filepath.Ext(eventFile.Name()) != ".json" {
+			continue
+		}
+
+		contents, err := afero.ReadFile(r.fs, r.hostJoin(runnerArtifactsDir, runnerIdent, "job_events", eventFile.Name()))
+		if err != nil {
+			return fmt.Errorf("failed to read %s job event, %w", ansible.RunnerProgram, err)
+		}
+
+		event, err := ansible.ParseRunnerEvent(contents)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		events = append(events, event)
+	}
+
+	artifact, err := ansible.RunnerPlaybookArtifact(ansible.ParseStatus(strings.TrimSpace(string(status))), string(stdout), events)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := r.writeFile(r.hostJoin(playbookArtifactFilename), string(artifact)); err != nil {
+		return fmt.Errorf("failed to save playbook artifact, %w", err)
+	}
+
+	return nil
+}
+
 func (r *Run) readPlaybookArtifact() ([]byte, error) {
 	if r.artifactContents != nil {
 		return r.artifactContents, nil
@@ -46,6 +252,17 @@ func (r *Run) readPlaybookArtifact() ([]byte, error) {
 	return contents, nil
 }
 
+// PlaybookArtifact parses the playbook artifact of the last attempt. Sensitive
+// values are masked as in Output, so nothing derived from it reveals them.
+func (r *Run) PlaybookArtifact() (*ansible.PlaybookArtifact, error) {
+	artifact, err := r.playbookArtifact()
+	if err != nil {
+		return nil, err
+	}
+
+	return r.redactor.RedactArtifact(artifact), nil
+}
+
 func (r *Run) playbookArtifact() (*ansible.PlaybookArtifact, error) {
 	contents, err := r.readPlaybookArtifact()
 	if err != nil {
@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
 )
@@ -16,9 +19,19 @@ func (r *Run) Preflight(ctx context.Context) error {
 		errs = append(errs, err)
 	}
 
+	if r.config.Requirements.Source != "" {
+		if err := r.checkRequirementsSource(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	errs = append(errs, r.checkSSHCertificates()...)
+
 	if r.config.mode().UsesEE() {
 		if err := r.checkContainerEngine(ctx); err != nil {
 			errs = append(errs, err)
+		} else if err := r.checkImage(ctx); err != nil {
+			errs = append(errs, err)
 		}
 	} else {
 		if err := r.checkPlaybookBinary(ctx); err != nil {
@@ -26,8 +39,14 @@ func (r *Run) Preflight(ctx context.Context) error {
 		}
 	}
 
-	if err := r.checkNavigatorBinary(ctx); err != nil {
-		errs = append(errs, err)
+	if r.config.mode() == ModeRunner {
+		if err := r.checkRunnerBinary(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	} else {
+		if err := r.checkNavigatorBinary(ctx); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
 	return errors.Join(errs...)
@@ -48,6 +67,47 @@ func (r *Run) checkWorkingDir() error {
 	return nil
 }
 
+// checkRequirementsSource resolves the source relative to the working
+// directory, as the host process and any container see different paths.
+func (r *Run) checkRequirementsSource() error {
+	source := r.config.Requirements.Source
+	if !filepath.IsAbs(source) {
+		if r.resolved.workingDir == "" {
+			return nil
+		}
+
+		source = filepath.Join(r.resolved.workingDir, source)
+	}
+
+	if _, err := r.fs.Stat(source); err != nil {
+		return newPreflightError(CheckRequirementsSource, "requirements source is not valid", err)
+	}
+
+	r.resolved.requirementsSource = source
+
+	return nil
+}
+
+// checkSSHCertificates catches expired certificates before the playbook runs,
+// as ssh would otherwise silently fall back to other authentication methods.
+func (r *Run) checkSSHCertificates() []error {
+	var errs []error
+
+	now := time.Now()
+
+	for _, key := range r.config.PrivateKeys {
+		if key.Certificate == "" {
+			continue
+		}
+
+		if err := ansible.CheckSSHCertificateValidity(key.Certificate, now); err != nil {
+			errs = append(errs, newPreflightError(CheckSSHCertificates, fmt.Sprintf("SSH certificate of private key '%s' is not valid", key.Name), err))
+		}
+	}
+
+	return errs
+}
+
 func (r *Run) checkContainerEngine(ctx context.Context) error {
 	engine := r.config.Settings.ExecutionEnvironment.ContainerEngine
 
@@ -55,15 +115,7 @@ func (r *Run) checkContainerEngine(ctx context.Context) error {
 		return newPreflightError(CheckContainerEngine, fmt.Sprintf("container engine %s not found in PATH", engine), nil)
 	}
 
-	if engine == ContainerEngineAuto {
-		for _, option := range containerEnginePrograms() {
-			if r.programExistsOnPath(option.String()) == nil {
-				engine = option
-
-				break
-			}
-		}
-	}
+	engine = resolveContainerEngine(r.exec, engine)
 
 	if engine == ContainerEngineAuto {
 		return newPreflightError(CheckContainerEngine, "no container engine found in PATH", nil)
@@ -73,6 +125,32 @@ func (r *Run) checkContainerEngine(ctx context.Context) error {
 		return newPreflightError(CheckContainerEngine, fmt.Sprintf("container engine is not running or usable, '%s info' command failed", engine), err)
 	}
 
+	r.resolved.containerEngine = engine
+
+	return nil
+}
+
+// checkImage resolves the digest of the image when it is already present. A
+// missing image is only a problem when ansible-navigator will not pull it, and
+// a present image without a repository digest is no problem at all.
+func (r *Run) checkImage(ctx context.Context) error {
+	execEnv := r.config.Settings.ExecutionEnvironment
+
+	if execEnv.RequireDigest && !ImageIsPinned(execEnv.Image) {
+		return newPreflightError(CheckImage, fmt.Sprintf("image '%s' is not pinned by digest, such as 'image@sha256:...'", execEnv.Image), nil)
+	}
+
+	digest, err := InspectImageDigest(ctx, r.exec, r.resolved.containerEngine, execEnv.Image)
+	if err != nil {
+		if execEnv.Pull.Policy == PullPolicyNever && !errors.Is(err, ErrImageNoDigest) {
+			return newPreflightError(CheckImage, fmt.Sprintf("image '%s' not found and pull policy is '%s'", execEnv.Image, PullPolicyNever), err)
+		}
+
+		return nil
+	}
+
+	r.ImageDigest = digest
+
 	return nil
 }
 
@@ -132,6 +210,47 @@ func (r *Run) checkNavigatorBinary(ctx context.Context) error {
 	return nil
 }
 
+func (r *Run) resolveRunnerBinary() error {
+	if r.config.RunnerBinary == "" {
+		path, err := r.exec.LookPath(ansible.RunnerProgram)
+		if err != nil {
+			return newPreflightError(CheckRunnerResolve, fmt.Sprintf("%s not found in PATH", ansible.RunnerProgram), nil)
+		}
+
+		r.resolved.runnerBinary = path
+
+		return nil
+	}
+
+	path, err := r.exec.Abs(r.config.RunnerBinary)
+	if err != nil {
+		return newPreflightError(CheckRunnerResolve, fmt.Sprintf("absolute path of %s cannot be determined", ansible.RunnerProgram), err)
+	}
+
+	r.resolved.runnerBinary = path
+
+	return nil
+}
+
+// checkRunnerBinary only expects a version number, as ansible-runner does not
+// print its name.
+func (r *Run) checkRunnerBinary(ctx context.Context) error {
+	if err := r.resolveRunnerBinary(); err != nil {
+		return err
+	}
+
+	stdoutStderr, err := r.exec.Run(ctx, ansible.Command{Name: r.resolved.runnerBinary, Args: []string{"--version"}})
+	if err != nil {
+		return newPreflightError(CheckRunnerBinary, fmt.Sprintf("'%s --version' command failed", r.resolved.runnerBinary), err)
+	}
+
+	if version := strings.TrimSpace(string(stdoutStderr)); version == "" || !unicode.IsDigit(rune(version[0])) {
+		return newPreflightError(CheckRunnerBinary, fmt.Sprintf("'%s --version' command output not expected", r.resolved.runnerBinary), nil)
+	}
+
+	return nil
+}
+
 func (r *Run) programExistsOnPath(program string) error {
 	_, err := r.exec.LookPath(program)
 
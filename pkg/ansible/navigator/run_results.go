@@ -16,15 +16,21 @@ func (r *Run) Query(queries map[string]ansible.PlaybookArtifactQuery) error {
 	var errs []error
 
 	for name, query := range queries {
-		results, err := ansible.QueryPlaybookArtifact(contents, query)
+		result, err := ansible.QueryPlaybookArtifact(contents, query)
 		if err != nil {
 			errs = append(errs, newQueryError(name, "failed to query playbook artifact", err))
 
 			continue
 		}
 
-		query.Results = results
-		queries[name] = query
+		result, err = r.redactor.RedactQuery(result)
+		if err != nil {
+			errs = append(errs, newQueryError(name, "failed to redact query results", err))
+
+			continue
+		}
+
+		queries[name] = result
 	}
 
 	return errors.Join(errs...)
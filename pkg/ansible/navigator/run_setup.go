@@ -1,14 +1,22 @@
 package navigator
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
 	"github.com/spf13/afero"
 )
 
-func (r *Run) Setup() error {
+// Setup writes the run directory, then installs any requirements. Installing
+// needs the settings file in place, and is skipped if a write failed.
+func (r *Run) Setup(ctx context.Context) error {
 	if err := r.createDirs(); err != nil {
 		return err
 	}
@@ -17,12 +25,18 @@ func (r *Run) Setup() error {
 		needed bool
 		write  func() error
 	}{
+		{len(r.config.Files) > 0, r.writeFiles},
 		{true, r.writePlaybook},
 		{true, r.writeInventories},
 		{len(r.config.ExtraVars) > 0, r.writeExtraVars},
 		{len(r.config.PrivateKeys) > 0, r.writePrivateKeys},
-		{r.config.UseKnownHosts, r.writeKnownHosts},
-		{true, r.writeSettings},
+		{len(r.config.sshAgentKeys()) > 0, r.startSSHAgent},
+		{len(r.config.VaultPasswords) > 0, r.writeVaultPasswords},
+		{r.config.usesKnownHosts(), r.writeKnownHosts},
+		{!r.config.SSHProxy.IsEmpty(), r.writeSSHProxyConfig},
+		{r.config.Requirements.Contents != "", r.writeRequirements},
+		{r.config.mode() != ModeRunner, r.writeSettings},
+		{r.config.mode() == ModeRunner, r.writeRunnerEnv},
 	}
 
 	var errs []error
@@ -37,7 +51,15 @@ func (r *Run) Setup() error {
 		}
 	}
 
-	return errors.Join(errs...)
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	if !r.config.Requirements.IsEmpty() {
+		return r.installRequirements(ctx)
+	}
+
+	return nil
 }
 
 func (r *Run) createDirs() error {
@@ -57,10 +79,44 @@ func (r *Run) createDirs() error {
 		return newSetupError(SetupDir, "failed to create private keys directory for run", err)
 	}
 
+	if err := r.fs.Mkdir(r.hostJoin(vaultPasswordDir), dirPermissions); err != nil {
+		return newSetupError(SetupDir, "failed to create vault passwords directory for run", err)
+	}
+
 	if err := r.fs.Mkdir(r.hostJoin(knownHostsDir), dirPermissions); err != nil {
 		return newSetupError(SetupDir, "failed to create known hosts directory for run", err)
 	}
 
+	if !r.config.SSHProxy.IsEmpty() {
+		if err := r.fs.Mkdir(r.hostJoin(sshProxyDir), dirPermissions); err != nil {
+			return newSetupError(SetupDir, "failed to create SSH proxy directory for run", err)
+		}
+	}
+
+	if len(r.config.Files) > 0 {
+		if err := r.fs.Mkdir(r.hostJoin(projectDir), dirPermissions); err != nil {
+			return newSetupError(SetupDir, "failed to create project directory for run", err)
+		}
+	}
+
+	if r.config.mode() == ModeRunner {
+		if err := r.fs.Mkdir(r.hostJoin(runnerEnvDir), dirPermissions); err != nil {
+			return newSetupError(SetupDir, "failed to create ansible-runner env directory for run", err)
+		}
+	}
+
+	if r.config.Requirements.IsEmpty() {
+		return nil
+	}
+
+	if err := r.fs.Mkdir(r.hostJoin(collectionsDir), dirPermissions); err != nil {
+		return newSetupError(SetupDir, "failed to create collections directory for run", err)
+	}
+
+	if err := r.fs.Mkdir(r.hostJoin(rolesDir), dirPermissions); err != nil {
+		return newSetupError(SetupDir, "failed to create roles directory for run", err)
+	}
+
 	return nil
 }
 
@@ -77,23 +133,91 @@ func (r *Run) writeSettings() error {
 	return nil
 }
 
+// writeRunnerEnv fills the env directory of the ansible-runner private data
+// directory, which takes the place of the navigator settings file.
+func (r *Run) writeRunnerEnv() error {
+	env := r.environment()
+	if r.config.HostKeyChecking != ansible.RunnerDefaultHostKeyChecking {
+		env["ANSIBLE_HOST_KEY_CHECKING"] = fmt.Sprintf("%t", r.config.HostKeyChecking)
+	}
+
+	files := map[string]any{
+		"envvars":  env,
+		"settings": map[string]any{"job_timeout": uint32(r.config.Settings.Timeout.Seconds())},
+		"cmdline":  shellJoin(r.playbookArgs()),
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		contents, err := json.Marshal(files[name])
+		if err != nil {
+			return newSetupError(SetupSettings, fmt.Sprintf("failed to generate ansible-runner %s for run", name), err)
+		}
+
+		if err := r.writeFile(r.hostJoin(runnerEnvDir, name), string(contents)); err != nil {
+			return newSetupError(SetupSettings, fmt.Sprintf("failed to create ansible-runner %s file for run", name), err)
+		}
+	}
+
+	return nil
+}
+
 func (r *Run) writePlaybook() error {
-	if err := r.writeFile(r.hostJoin(playbookFilename), r.config.Playbook); err != nil {
+	if err := r.writeFile(r.hostJoin(r.playbookFile()), r.config.Playbook); err != nil {
 		return newSetupError(SetupPlaybook, "failed to create playbook file for run", err)
 	}
 
 	return nil
 }
 
+func (r *Run) writeFiles() error {
+	var errs []error
+
+	for _, file := range r.config.Files {
+		if err := ValidateProjectFilePath(file.Path); err != nil {
+			errs = append(errs, newSetupItemError(SetupFiles, file.Path, "project file path is not valid", err))
+
+			continue
+		}
+
+		mode := file.Mode
+		if mode == 0 {
+			mode = filePermissions
+		}
+
+		path := r.hostJoin(projectDir, filepath.FromSlash(file.Path))
+
+		if err := r.fs.MkdirAll(filepath.Dir(path), dirPermissions); err != nil {
+			errs = append(errs, newSetupItemError(SetupFiles, file.Path, "failed to create project file directory for run", err))
+
+			continue
+		}
+
+		if err := afero.WriteFile(r.fs, path, []byte(file.Contents), mode); err != nil {
+			errs = append(errs, newSetupItemError(SetupFiles, file.Path, "failed to create project file for run", err))
+
+			continue
+		}
+
+		// WriteFile leaves the mode to the umask.
+		if err := r.fs.Chmod(path, mode); err != nil {
+			errs = append(errs, newSetupItemError(SetupFiles, file.Path, "failed to set project file mode for run", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 func (r *Run) writeInventories() error {
+	var errs []error
+
 	for _, inventory := range r.config.Inventories {
 		err := r.writeFile(r.hostJoin(inventoriesDir, inventory.Name), inventory.Contents)
 		if err != nil {
-			return newSetupError(SetupInventories, "failed to create ansible inventory file for run", err)
+			errs = append(errs, newSetupItemError(SetupInventories, inventory.Name, "failed to create ansible inventory file for run", err))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 func (r *Run) writeExtraVars() error {
@@ -107,20 +231,64 @@ func (r *Run) writeExtraVars() error {
 	return nil
 }
 
+// writePrivateKeys skips keys served by the SSH agent, which holds their
+// certificates as well.
 func (r *Run) writePrivateKeys() error {
 	for _, key := range r.config.PrivateKeys {
+		if r.config.usesSSHAgent(key) {
+			continue
+		}
+
 		err := r.writeFile(r.hostJoin(privateKeysDir, key.Name), key.Data)
 		if err != nil {
 			return newSetupError(SetupPrivateKeys, "failed to create private key file for run", err)
 		}
+
+		if key.Certificate == "" {
+			continue
+		}
+
+		err = r.writeFile(r.hostJoin(privateKeysDir, key.Name+ansible.SSHCertificateSuffix), key.Certificate)
+		if err != nil {
+			return newSetupError(SetupPrivateKeys, "failed to create SSH certificate file for run", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Run) startSSHAgent() error {
+	sshAgent, err := ansible.StartSSHAgent(r.hostJoin(sshAgentSocket), r.config.sshAgentKeys())
+	if err != nil {
+		return newSetupError(SetupSSHAgent, "failed to start SSH agent for run", err)
+	}
+
+	r.sshAgent = sshAgent
+
+	return nil
+}
+
+func (r *Run) writeVaultPasswords() error {
+	for _, password := range r.config.VaultPasswords {
+		err := r.writeFile(r.hostJoin(vaultPasswordDir, password.ID), password.Password)
+		if err != nil {
+			return newSetupError(SetupVaultPasswords, "failed to create vault password file for run", err)
+		}
 	}
 
 	return nil
 }
 
 func (r *Run) writeKnownHosts() error {
+	knownHosts := slices.Clone(r.config.KnownHosts)
+	for _, knownHost := range r.config.SSHProxy.KnownHosts {
+		if !slices.Contains(knownHosts, knownHost) {
+			knownHosts = append(knownHosts, knownHost)
+		}
+	}
+
 	path := r.hostJoin(knownHostsDir, knownHostsFile)
-	err := r.writeFile(path, strings.Join(r.config.KnownHosts, "\n"))
+	err := r.writeFile(path, strings.Join(knownHosts, "\n"))
 	if err != nil {
 		return newSetupError(SetupKnownHosts, "failed to create known hosts file for run", err)
 	}
@@ -128,6 +296,130 @@ func (r *Run) writeKnownHosts() error {
 	return nil
 }
 
+// writeSSHProxyConfig points the proxy at a private key by name, which must be
+// one of the private keys of the run. A key served by the SSH agent has no
+// file, so ssh offers the keys of the agent instead.
+func (r *Run) writeSSHProxyConfig() error {
+	proxy := r.config.SSHProxy
+
+	var identityFile string
+
+	if proxy.PrivateKeyName != "" {
+		index := slices.IndexFunc(r.config.PrivateKeys, func(key ansible.PrivateKey) bool { return key.Name == proxy.PrivateKeyName })
+		if index < 0 {
+			return newSetupError(SetupSSHProxy, fmt.Sprintf("SSH proxy private key '%s' not found in private keys", proxy.PrivateKeyName), nil)
+		}
+
+		if !r.config.usesSSHAgent(r.config.PrivateKeys[index]) {
+			identityFile = r.playbookJoin(privateKeysDir, proxy.PrivateKeyName)
+		}
+	}
+
+	contents := proxy.Config(identityFile, r.playbookJoin(knownHostsDir, knownHostsFile))
+	if err := r.writeFile(r.hostJoin(sshProxyDir, sshProxyConfig), contents); err != nil {
+		return newSetupError(SetupSSHProxy, "failed to create SSH proxy config file for run", err)
+	}
+
+	return nil
+}
+
+func (r *Run) writeRequirements() error {
+	if err := r.writeFile(r.hostJoin(requirementsFilename), r.config.Requirements.Contents); err != nil {
+		return newSetupError(SetupRequirements, "failed to create requirements file for run", err)
+	}
+
+	return nil
+}
+
+func (r *Run) installRequirements(ctx context.Context) error {
+	for _, command := range r.galaxyCommands() {
+		output, err := r.runCommand(ctx, command)
+		if err != nil {
+			return newSetupError(SetupRequirements, fmt.Sprintf("failed to install requirements, '%s' command failed", ansible.GalaxyProgram), fmt.Errorf("%w, output: %s", err, strings.TrimSpace(string(output))))
+		}
+	}
+
+	return nil
+}
+
+// galaxyCommands runs ansible-galaxy directly in host mode. In EE mode the
+// installs must happen inside the image, so they are chained into a single
+// navigator exec to start the container once.
+func (r *Run) galaxyCommands() []ansible.Command {
+	var installs [][]string
+
+	if r.config.Requirements.Source != "" {
+		source := r.resolved.requirementsSource
+		if r.config.mode().UsesEE() {
+			source = r.requirementsSourcePath()
+		}
+
+		installs = append(installs, []string{"collection", "install", "--offline", "-p", r.playbookJoin(collectionsDir), source})
+	}
+
+	if r.config.Requirements.Contents != "" {
+		installs = append(installs,
+			[]string{"collection", "install", "-r", r.playbookJoin(requirementsFilename), "-p", r.playbookJoin(collectionsDir)},
+			[]string{"role", "install", "-r", r.playbookJoin(requirementsFilename), "-p", r.playbookJoin(rolesDir)},
+		)
+	}
+
+	base := ansible.Command{
+		Dir: r.resolved.workingDir,
+		Env: r.exec.Environ(),
+	}
+
+	env := r.environment()
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		base = base.AppendEnv(name, env[name])
+	}
+
+	if !r.config.mode().UsesEE() {
+		commands := make([]ansible.Command, 0, len(installs))
+		for _, install := range installs {
+			command := base
+			command.Name = ansible.GalaxyProgram
+			command.Args = install
+			commands = append(commands, command)
+		}
+
+		return commands
+	}
+
+	shellCommands := make([]string, 0, len(installs))
+	for _, install := range installs {
+		shellCommands = append(shellCommands, shellJoin(append([]string{ansible.GalaxyProgram}, install...)))
+	}
+
+	command := base
+	command.Name = r.resolved.navigatorBinary
+	command.Args = []string{"exec", "--log-file", r.navigatorJoin(navigatorLogFilename), "--", strings.Join(shellCommands, " && ")}
+	command = command.AppendEnv("ANSIBLE_NAVIGATOR_CONFIG", r.navigatorJoin(navigatorSettingsFilename))
+
+	return []ansible.Command{command}
+}
+
+func (r *Run) requirementsSourcePath() string {
+	return runDir{root: containerRequirementsSourceDir, container: true}.join(filepath.Base(r.resolved.requirementsSource))
+}
+
+// shellJoin quotes only the arguments that need it, keeping the logged exec
+// command readable.
+func shellJoin(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@,") == "" {
+			quoted = append(quoted, arg)
+
+			continue
+		}
+
+		quoted = append(quoted, "'"+strings.ReplaceAll(arg, "'", `'\''`)+"'")
+	}
+
+	return strings.Join(quoted, " ")
+}
+
 func (r *Run) writeFile(path string, contents string) error {
 	if err := afero.WriteFile(r.fs, path, []byte(contents), filePermissions); err != nil {
 		return fmt.Errorf("failed to write file, %w", err)
@@ -2,14 +2,23 @@ package navigator
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"net"
 	"os"
+	"path/filepath"
 	"reflect"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
 	"github.com/spf13/afero"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
 )
 
 const testHostDir = "/tmp/ansible-navigator-run-test"
@@ -25,6 +34,8 @@ func testConfig(eeEnabled bool) RunConfig {
 		},
 		ExtraVars:       []ansible.ExtraVarsFile{{Name: "vars.yaml", Contents: "key: value\n"}},
 		PrivateKeys:     []ansible.PrivateKey{{Name: "key", Data: "PRIVATE KEY\n"}},
+		VaultPasswords:  []ansible.VaultPassword{{ID: "default", Password: "secret"}, {ID: "prod", Password: "prod-secret"}},
+		Requirements:    ansible.Requirements{Contents: "collections:\n- community.general\n", Source: "example-1.0.0.tar.gz"},
 		KnownHosts:      []ansible.KnownHost{"example.com ssh-ed25519 AAAA"},
 		UseKnownHosts:   true,
 		HostKeyChecking: true,
@@ -59,6 +70,21 @@ func testConfig(eeEnabled bool) RunConfig {
 func newTestRun(t *testing.T, eeEnabled bool) (*Run, *fakeExecutor) {
 	t.Helper()
 
+	return newTestRunWithConfig(t, testConfig(eeEnabled))
+}
+
+func newTestRunnerRun(t *testing.T) (*Run, *fakeExecutor) {
+	t.Helper()
+
+	config := testConfig(false)
+	config.UseRunner = true
+
+	return newTestRunWithConfig(t, config)
+}
+
+func newTestRunWithConfig(t *testing.T, config RunConfig) (*Run, *fakeExecutor) {
+	t.Helper()
+
 	memFs := afero.NewMemMapFs()
 	if err := memFs.MkdirAll("/tmp", dirPermissions); err != nil {
 		t.Fatalf("failed to create tmp directory: %v", err)
@@ -68,12 +94,17 @@ func newTestRun(t *testing.T, eeEnabled bool) (*Run, *fakeExecutor) {
 		t.Fatalf("failed to create working directory: %v", err)
 	}
 
+	if err := afero.WriteFile(memFs, "/work/example-1.0.0.tar.gz", nil, filePermissions); err != nil {
+		t.Fatalf("failed to create requirements source: %v", err)
+	}
+
 	exec := newFakeExecutor().
-		withProgram(ContainerEnginePodman.String(), ContainerEngineDocker.String(), ansible.PlaybookProgram, Program).
+		withProgram(ContainerEnginePodman.String(), ContainerEngineDocker.String(), ansible.PlaybookProgram, Program, ansible.RunnerProgram).
 		withResponse(Program+" --version", Program+" 26.6.0", nil).
-		withResponse(ansible.PlaybookProgram+" --version", ansible.PlaybookProgram+" [core 2.19.0]", nil)
+		withResponse(ansible.PlaybookProgram+" --version", ansible.PlaybookProgram+" [core 2.19.0]", nil).
+		withResponse(ansible.RunnerProgram+" --version", "2.4.1", nil)
 
-	run := NewRun(testHostDir, testConfig(eeEnabled), WithFs(memFs), WithExecutor(exec))
+	run := NewRun(testHostDir, config, WithFs(memFs), WithExecutor(exec))
 
 	// Not in sorted order, so the goldens pin that the run sorts them.
 	run.SetEnv("ZULU_VAR", "zulu-value")
@@ -109,6 +140,7 @@ func TestPreflightCommands(t *testing.T) {
 			eeEnabled: true,
 			want: []string{
 				"podman info",
+				"podman image inspect --format {{range .RepoDigests}}{{println .}}{{end}} ghcr.io/ansible/community-ansible-dev-tools:v26.7.1",
 				"/usr/bin/ansible-navigator --version",
 			},
 		},
@@ -135,6 +167,7 @@ func TestSetupCreatesRunDirectory(t *testing.T) {
 	want := []string{
 		testHostDir + "/",
 		testHostDir + "/ansible-navigator.yaml",
+		testHostDir + "/collections/",
 		testHostDir + "/extra-vars/",
 		testHostDir + "/extra-vars/vars.yaml",
 		testHostDir + "/inventories/",
@@ -145,6 +178,11 @@ func TestSetupCreatesRunDirectory(t *testing.T) {
 		testHostDir + "/playbook.yaml",
 		testHostDir + "/private-keys/",
 		testHostDir + "/private-keys/key",
+		testHostDir + "/requirements.yml",
+		testHostDir + "/roles/",
+		testHostDir + "/vault-passwords/",
+		testHostDir + "/vault-passwords/default",
+		testHostDir + "/vault-passwords/prod",
 	}
 
 	for name, eeEnabled := range map[string]bool{"host": false, "ee": true} {
@@ -153,7 +191,7 @@ func TestSetupCreatesRunDirectory(t *testing.T) {
 
 			run, _ := newTestRun(t, eeEnabled)
 
-			if err := run.Setup(); err != nil {
+			if err := run.Setup(context.Background()); err != nil {
 				t.Fatalf("setup failed: %v", err)
 			}
 
@@ -182,6 +220,436 @@ func TestSetupCreatesRunDirectory(t *testing.T) {
 	}
 }
 
+// failingFs fails writes to a single path.
+type failingFs struct {
+	afero.Fs
+
+	path string
+}
+
+func (f failingFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if name == f.path {
+		return nil, os.ErrPermission
+	}
+
+	return f.Fs.OpenFile(name, flag, perm) //nolint:wrapcheck
+}
+
+func TestSetupInventoryErrorNames(t *testing.T) {
+	t.Parallel()
+
+	run, _ := newTestRun(t, false)
+	run.fs = failingFs{Fs: run.fs, path: run.hostJoin(inventoriesDir, "previous-hosts")}
+
+	var setupErr *SetupError
+	if err := run.Setup(context.Background()); !errors.As(err, &setupErr) {
+		t.Fatalf("expected setup error, got %v", err)
+	}
+
+	if setupErr.Step != SetupInventories || setupErr.Name != "previous-hosts" {
+		t.Errorf("expected inventories step for 'previous-hosts', got %d for '%s'", setupErr.Step, setupErr.Name)
+	}
+}
+
+func TestSetupWritesProjectFiles(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig(true)
+	config.Files = []ansible.ProjectFile{
+		{Path: "group_vars/all.yml", Contents: "key: value\n"},
+		{Path: "roles/example/tasks/main.yml", Contents: "- ansible.builtin.ping:\n"},
+		{Path: "scripts/hello.sh", Contents: "#!/bin/sh\n", Mode: 0o755},
+	}
+
+	run, _ := newTestRunWithConfig(t, config)
+
+	if err := run.Setup(context.Background()); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	modes := map[string]os.FileMode{
+		"playbook.yaml":                filePermissions,
+		"group_vars/all.yml":           filePermissions,
+		"roles/example/tasks/main.yml": filePermissions,
+		"scripts/hello.sh":             0o755,
+	}
+	for name, mode := range modes {
+		info, err := run.fs.Stat(run.hostJoin(projectDir, name))
+		if err != nil {
+			t.Fatalf("failed to stat %s: %v", name, err)
+		}
+
+		if info.Mode().Perm() != mode {
+			t.Errorf("%s: want mode %o, got %o", name, mode, info.Mode().Perm())
+		}
+	}
+
+	if exists, _ := afero.Exists(run.fs, run.hostJoin(playbookFilename)); exists {
+		t.Error("expected playbook within the project directory only")
+	}
+
+	if got, want := run.command().Args[1], testHostDir+"/project/playbook.yaml"; got != want {
+		t.Errorf("want playbook %s, got %s", want, got)
+	}
+}
+
+func TestSetupRejectsProjectFileTraversal(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig(false)
+	config.Files = []ansible.ProjectFile{{Path: "../escape.yml", Contents: "escaped\n"}}
+
+	run, _ := newTestRunWithConfig(t, config)
+
+	var setupErr *SetupError
+	if err := run.Setup(context.Background()); !errors.As(err, &setupErr) {
+		t.Fatalf("expected setup error, got %v", err)
+	}
+
+	if setupErr.Step != SetupFiles || setupErr.Name != "../escape.yml" {
+		t.Errorf("expected files step for '../escape.yml', got %d for '%s'", setupErr.Step, setupErr.Name)
+	}
+
+	if exists, _ := afero.Exists(run.fs, run.hostJoin("escape.yml")); exists {
+		t.Error("expected file outside the project directory not to be written")
+	}
+}
+
+// testSSHCertificate returns a user certificate for a throwaway key, signed by
+// a throwaway certificate authority.
+func testSSHCertificate(t *testing.T, validBefore time.Time) string {
+	t.Helper()
+
+	publicKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := gossh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, caPrivateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caSigner, err := gossh.NewSignerFromKey(caPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := &gossh.Certificate{
+		Key:         key,
+		CertType:    gossh.UserCert,
+		ValidBefore: uint64(validBefore.Unix()), //nolint:gosec
+	}
+
+	if err := cert.SignCert(rand.Reader, caSigner); err != nil {
+		t.Fatal(err)
+	}
+
+	return string(gossh.MarshalAuthorizedKey(cert))
+}
+
+func TestSetupWritesSSHCertificate(t *testing.T) {
+	t.Parallel()
+
+	cert := testSSHCertificate(t, time.Now().Add(time.Hour))
+
+	config := testConfig(false)
+	config.PrivateKeys = []ansible.PrivateKey{
+		{Name: "key", Data: "PRIVATE KEY\n", Certificate: cert},
+		{Name: "other", Data: "PRIVATE KEY\n"},
+	}
+
+	run, _ := newTestRunWithConfig(t, config)
+
+	if err := run.Setup(context.Background()); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	contents, err := afero.ReadFile(run.fs, run.hostJoin(privateKeysDir, "key-cert.pub"))
+	if err != nil {
+		t.Fatalf("failed to read certificate: %v", err)
+	}
+
+	if string(contents) != cert {
+		t.Errorf("unexpected certificate contents %q", contents)
+	}
+
+	if exists, _ := afero.Exists(run.fs, run.hostJoin(privateKeysDir, "other-cert.pub")); exists {
+		t.Error("expected no certificate for key without one")
+	}
+}
+
+// The agent listens on a real socket, so the run uses the OS filesystem.
+func TestSetupStartsSSHAgent(t *testing.T) {
+	t.Parallel()
+
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted, err := gossh.MarshalPrivateKeyWithPassphrase(privateKey, "", []byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unencrypted, err := gossh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := gossh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]RunConfig{
+		"passphrase": {
+			PrivateKeys: []ansible.PrivateKey{{Name: "key", Data: string(pem.EncodeToMemory(encrypted)), Passphrase: "secret"}},
+		},
+		"use_ssh_agent": {
+			PrivateKeys: []ansible.PrivateKey{{Name: "key", Data: string(pem.EncodeToMemory(unencrypted))}},
+			UseSSHAgent: true,
+		},
+	}
+
+	for name, config := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config.Playbook = "- hosts: all\n"
+
+			run := NewRun(filepath.Join(t.TempDir(), "run"), config, WithExecutor(newFakeExecutor()))
+
+			if err := run.Setup(context.Background()); err != nil {
+				t.Fatalf("setup failed: %v", err)
+			}
+
+			if exists, _ := afero.Exists(run.fs, run.hostJoin(privateKeysDir, "key")); exists {
+				t.Error("expected no private key file for key served by the SSH agent")
+			}
+
+			if command := run.command().String(); strings.Contains(command, "--private-key") {
+				t.Errorf("expected no --private-key for key served by the SSH agent, got %s", command)
+			}
+
+			socketPath := run.environment()[ansible.SSHAuthSockEnvVar]
+			if socketPath != run.hostJoin(sshAgentSocket) {
+				t.Errorf("unexpected %s %q", ansible.SSHAuthSockEnvVar, socketPath)
+			}
+
+			conn, err := net.Dial("unix", socketPath) //nolint:noctx
+			if err != nil {
+				t.Fatalf("failed to connect to agent socket: %v", err)
+			}
+
+			keys, err := agent.NewClient(conn).List()
+			if err != nil {
+				t.Fatalf("failed to list agent keys: %v", err)
+			}
+
+			if err := conn.Close(); err != nil {
+				t.Fatalf("failed to close agent connection: %v", err)
+			}
+
+			if len(keys) != 1 || string(keys[0].Blob) != string(signer.PublicKey().Marshal()) {
+				t.Errorf("expected agent to serve the private key, got %v", keys)
+			}
+
+			if err := run.Cleanup(); err != nil {
+				t.Fatalf("cleanup failed: %v", err)
+			}
+
+			if _, err := os.Stat(run.HostDir()); !os.IsNotExist(err) {
+				t.Errorf("expected run directory to be removed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSetupWritesSSHProxyConfig(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig(true)
+	config.SSHProxy = ansible.SSHProxy{
+		Host:           "bastion.example.com",
+		Port:           2222,
+		User:           "jump",
+		PrivateKeyName: "key",
+		KnownHosts:     []ansible.KnownHost{"[bastion.example.com]:2222 ssh-ed25519 AAAA", "example.com ssh-ed25519 AAAA"},
+	}
+
+	run, _ := newTestRunWithConfig(t, config)
+
+	if err := run.Setup(context.Background()); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	contents, err := afero.ReadFile(run.fs, run.hostJoin(sshProxyDir, sshProxyConfig))
+	if err != nil {
+		t.Fatalf("failed to read SSH proxy config: %v", err)
+	}
+
+	assertGolden(t, "ssh-proxy/ssh_config", string(contents))
+
+	knownHosts, err := run.ReadKnownHosts()
+	if err != nil {
+		t.Fatalf("failed to read known hosts: %v", err)
+	}
+
+	assertLines(t, "known hosts", knownHosts, []string{"example.com ssh-ed25519 AAAA", "[bastion.example.com]:2222 ssh-ed25519 AAAA"})
+
+	if args := run.playbookArgs(); !slices.Contains(args, ansible.SSHProxyConfigFileVar+"="+run.playbookJoin(sshProxyDir, sshProxyConfig)) {
+		t.Errorf("expected SSH proxy config file var in %q", args)
+	}
+}
+
+func TestSetupSSHProxyUnknownPrivateKey(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig(false)
+	config.SSHProxy = ansible.SSHProxy{Host: "bastion.example.com", PrivateKeyName: "missing"}
+
+	run, _ := newTestRunWithConfig(t, config)
+
+	var setupErr *SetupError
+	if err := run.Setup(context.Background()); !errors.As(err, &setupErr) || setupErr.Step != SetupSSHProxy {
+		t.Fatalf("expected SSH proxy setup error, got %v", err)
+	}
+}
+
+func TestPreflightExpiredSSHCertificate(t *testing.T) {
+	t.Parallel()
+
+	config := testConfig(false)
+	config.PrivateKeys = []ansible.PrivateKey{
+		{Name: "valid", Data: "PRIVATE KEY\n", Certificate: testSSHCertificate(t, time.Now().Add(time.Hour))},
+		{Name: "expired", Data: "PRIVATE KEY\n", Certificate: testSSHCertificate(t, time.Now().Add(-time.Hour))},
+	}
+
+	run, _ := newTestRunWithConfig(t, config)
+
+	var preflightErr *PreflightError
+	if err := run.Preflight(context.Background()); !errors.As(err, &preflightErr) {
+		t.Fatalf("expected preflight error, got %v", err)
+	}
+
+	if preflightErr.Check != CheckSSHCertificates || !strings.Contains(preflightErr.Error(), "private key 'expired'") || !strings.Contains(preflightErr.Error(), "expired at") {
+		t.Errorf("unexpected preflight error: %v", preflightErr)
+	}
+}
+
+func TestPreflightImageDigest(t *testing.T) {
+	t.Parallel()
+
+	const testDigest = "4a5b6c4a5b6c4a5b6c4a5b6c4a5b6c4a5b6c4a5b6c4a5b6c4a5b6c4a5b6c4a5b"
+
+	const pinned = "ghcr.io/ansible/community-ansible-dev-tools@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	tests := map[string]struct {
+		image         string
+		policy        PullPolicy
+		requireDigest bool
+		output        string
+		err           error
+		wantDigest    string
+		wantErr       string
+	}{
+		"repo_digest": {
+			output:     "ghcr.io/ansible/community-ansible-dev-tools@sha256:" + testDigest + "\n",
+			wantDigest: "sha256:" + testDigest,
+		},
+		// Docker shortens Docker Hub names, Podman does not.
+		"own_repository_preferred": {
+			image:      "docker.io/library/alpine:3",
+			output:     "mirror.example.com/alpine@sha256:" + strings.Repeat("0", 64) + "\nalpine@sha256:" + testDigest + "\n",
+			wantDigest: "sha256:" + testDigest,
+		},
+		"no_repo_digest": {
+			output: "\n",
+		},
+		"no_repo_digest_never_pulled": {
+			policy: PullPolicyNever,
+			output: "\n",
+		},
+		"missing_pulled": {
+			err: errors.New("image not known"),
+		},
+		"missing_never_pulled": {
+			policy:  PullPolicyNever,
+			err:     errors.New("image not known"),
+			wantErr: "pull policy is 'never'",
+		},
+		"require_digest_pinned": {
+			image:         pinned,
+			requireDigest: true,
+			output:        pinned,
+			wantDigest:    "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		},
+		"require_digest_tag": {
+			requireDigest: true,
+			wantErr:       "not pinned by digest",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			config := testConfig(true)
+			config.Settings.ExecutionEnvironment.RequireDigest = test.requireDigest
+
+			if test.image != "" {
+				config.Settings.ExecutionEnvironment.Image = test.image
+			}
+
+			if test.policy != "" {
+				config.Settings.ExecutionEnvironment.Pull.Policy = test.policy
+			}
+
+			run, exec := newTestRunWithConfig(t, config)
+			exec.withResponse("image inspect", test.output, test.err)
+
+			err := run.Preflight(context.Background())
+
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("preflight failed: %v", err)
+				}
+			} else {
+				var preflightErr *PreflightError
+				if !errors.As(err, &preflightErr) || preflightErr.Check != CheckImage || !strings.Contains(preflightErr.Error(), test.wantErr) {
+					t.Fatalf("expected image preflight error containing %q, got %v", test.wantErr, err)
+				}
+			}
+
+			if run.ImageDigest != test.wantDigest {
+				t.Errorf("want digest %q, got %q", test.wantDigest, run.ImageDigest)
+			}
+		})
+	}
+}
+
+func TestImageIsPinned(t *testing.T) {
+	t.Parallel()
+
+	for image, want := range map[string]bool{
+		"ghcr.io/ansible/community-ansible-dev-tools:v26.7.1":                                                                         false,
+		"ghcr.io/ansible/community-ansible-dev-tools@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef":         true,
+		"ghcr.io/ansible/community-ansible-dev-tools:v26.7.1@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef": true,
+		"not a valid image": false,
+	} {
+		if got := ImageIsPinned(image); got != want {
+			t.Errorf("%q: want %t, got %t", image, want, got)
+		}
+	}
+}
+
 func TestSettingsGenerate(t *testing.T) {
 	t.Parallel()
 
@@ -197,7 +665,7 @@ func TestSettingsGenerate(t *testing.T) {
 				t.Fatalf("preflight failed: %v", err)
 			}
 
-			if err := run.Setup(); err != nil {
+			if err := run.Setup(context.Background()); err != nil {
 				t.Fatalf("setup failed: %v", err)
 			}
 
@@ -214,12 +682,16 @@ func TestSettingsGenerate(t *testing.T) {
 func TestNavigatorCommand(t *testing.T) {
 	t.Parallel()
 
-	wantEnv := []string{
-		"ANSIBLE_NAVIGATOR_CONFIG=" + testHostDir + "/ansible-navigator.yaml",
-		"ALPHA_VAR=alpha-value",
-		"EXAMPLE_VAR=example-value",
-		"ZULU_VAR=zulu-value",
-		"ANSIBLE_HOST_KEY_CHECKING=true",
+	wantEnv := func(runDir string) []string {
+		return []string{
+			"ANSIBLE_NAVIGATOR_CONFIG=" + testHostDir + "/ansible-navigator.yaml",
+			"ALPHA_VAR=alpha-value",
+			"ANSIBLE_COLLECTIONS_PATH=" + runDir + "/collections:~/.ansible/collections:/usr/share/ansible/collections",
+			"ANSIBLE_ROLES_PATH=" + runDir + "/roles:~/.ansible/roles:/usr/share/ansible/roles:/etc/ansible/roles",
+			"EXAMPLE_VAR=example-value",
+			"ZULU_VAR=zulu-value",
+			"ANSIBLE_HOST_KEY_CHECKING=true",
+		}
 	}
 
 	for name, eeEnabled := range map[string]bool{"host": false, "ee": true} {
@@ -232,14 +704,14 @@ func TestNavigatorCommand(t *testing.T) {
 				t.Fatalf("preflight failed: %v", err)
 			}
 
-			if err := run.Setup(); err != nil {
+			if err := run.Setup(context.Background()); err != nil {
 				t.Fatalf("setup failed: %v", err)
 			}
 
 			command := run.navigatorCommand()
 
 			assertGoldenLines(t, "command/"+name+".txt", append([]string{command.Name}, command.Args...))
-			assertLines(t, "env", exec.envDelta(command), wantEnv)
+			assertLines(t, "env", exec.envDelta(command), wantEnv(run.PlaybookDir()))
 
 			if command.Dir != "/work" {
 				t.Errorf("expected command dir %q, got %q", "/work", command.Dir)
@@ -248,6 +720,152 @@ func TestNavigatorCommand(t *testing.T) {
 	}
 }
 
+func TestGalaxyCommands(t *testing.T) {
+	t.Parallel()
+
+	for name, eeEnabled := range map[string]bool{"host": false, "ee": true} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			run, exec := newTestRun(t, eeEnabled)
+
+			if err := run.Preflight(context.Background()); err != nil {
+				t.Fatalf("preflight failed: %v", err)
+			}
+
+			commandsBefore := len(exec.commands)
+
+			if err := run.Setup(context.Background()); err != nil {
+				t.Fatalf("setup failed: %v", err)
+			}
+
+			assertGoldenLines(t, "command/galaxy-"+name+".txt", exec.commandStrings()[commandsBefore:])
+
+			for _, command := range exec.commands[commandsBefore:] {
+				if command.Dir != "/work" {
+					t.Errorf("expected command dir %q, got %q", "/work", command.Dir)
+				}
+			}
+		})
+	}
+}
+
+func TestShellJoin(t *testing.T) {
+	t.Parallel()
+
+	got := shellJoin([]string{"ansible-galaxy", "/tmp/run/my collection.tar.gz", "it's", ""})
+	want := `ansible-galaxy '/tmp/run/my collection.tar.gz' 'it'\''s' ''`
+
+	if got != want {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestSetupRequirementsInstallFails(t *testing.T) {
+	t.Parallel()
+
+	run, exec := newTestRun(t, false)
+	exec.withResponse(ansible.GalaxyProgram+" collection install -r", "ERROR! collection not found", errors.New("exit status 1"))
+
+	if err := run.Preflight(context.Background()); err != nil {
+		t.Fatalf("preflight failed: %v", err)
+	}
+
+	var setupErr *SetupError
+	if err := run.Setup(context.Background()); !errors.As(err, &setupErr) {
+		t.Fatalf("expected setup error, got %v", err)
+	}
+
+	if setupErr.Step != SetupRequirements {
+		t.Errorf("expected requirements step, got %d", setupErr.Step)
+	}
+
+	if !strings.Contains(setupErr.Error(), "collection not found") {
+		t.Errorf("expected error to include command output, got %q", setupErr.Error())
+	}
+}
+
+func TestRunnerSetup(t *testing.T) {
+	t.Parallel()
+
+	run, exec := newTestRunnerRun(t)
+
+	if err := run.Preflight(context.Background()); err != nil {
+		t.Fatalf("preflight failed: %v", err)
+	}
+
+	if slices.ContainsFunc(exec.commandStrings(), func(command string) bool { return strings.HasPrefix(command, "/usr/bin/"+Program) }) {
+		t.Errorf("expected no %s commands, got %q", Program, exec.commandStrings())
+	}
+
+	if err := run.Setup(context.Background()); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	if exists, _ := afero.Exists(run.fs, run.hostJoin(navigatorSettingsFilename)); exists {
+		t.Error("expected no navigator settings file")
+	}
+
+	for _, name := range []string{"cmdline", "envvars", "settings"} {
+		contents, err := afero.ReadFile(run.fs, run.hostJoin(runnerEnvDir, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+
+		assertGolden(t, "runner/"+name, string(contents))
+	}
+
+	command := run.command()
+	assertGoldenLines(t, "command/runner.txt", append([]string{command.Name}, command.Args...))
+}
+
+func TestRunnerExecuteBuildsArtifact(t *testing.T) {
+	t.Parallel()
+
+	run, _ := newTestRunnerRun(t)
+
+	if err := run.Preflight(context.Background()); err != nil {
+		t.Fatalf("preflight failed: %v", err)
+	}
+
+	if err := run.Setup(context.Background()); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	// Stands in for the artifacts ansible-runner writes.
+	files := map[string]string{
+		"status":                 "successful\n",
+		"stdout":                 "PLAY [all] ***\n",
+		"job_events/1-play.json": `{"counter":1,"event":"playbook_on_play_start","event_data":{"play":"all","play_uuid":"p1"}}`,
+		"job_events/2-ok.json":   `{"counter":2,"event":"runner_on_ok","event_data":{"play":"all","play_uuid":"p1","task":"ping","host":"a","res":{"changed":false}}}`,
+	}
+	for name, contents := range files {
+		if err := afero.WriteFile(run.fs, run.hostJoin(runnerArtifactsDir, runnerIdent, name), []byte(contents), filePermissions); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	if err := run.Execute(context.Background()); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+
+	artifact, err := run.PlaybookArtifact()
+	if err != nil {
+		t.Fatalf("failed to read playbook artifact: %v", err)
+	}
+
+	if artifact.Status != ansible.StatusSuccessful || len(artifact.TaskResults()) != 1 {
+		t.Errorf("unexpected playbook artifact: %+v", artifact)
+	}
+
+	queries := map[string]ansible.PlaybookArtifactQuery{"host": {JQFilter: ".plays[0].tasks[0].host", Raw: true}}
+	if err := run.Query(queries); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+
+	assertLines(t, "query results", queries["host"].Results, []string{"a"})
+}
+
 func TestRunDirs(t *testing.T) {
 	t.Parallel()
 
@@ -315,7 +933,7 @@ func TestCleanupRemovesRunDirectory(t *testing.T) {
 
 	run, _ := newTestRun(t, false)
 
-	if err := run.Setup(); err != nil {
+	if err := run.Setup(context.Background()); err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
@@ -333,6 +951,92 @@ func TestCleanupRemovesRunDirectory(t *testing.T) {
 	}
 }
 
+func TestExecuteStreamsOutput(t *testing.T) {
+	t.Parallel()
+
+	var lines []string
+
+	exec := newFakeExecutor().withResponse("run "+testHostDir, "PLAY [all]\nTASK [ping]\nok: [localhost]\n", nil)
+	run := NewRun(testHostDir, testConfig(false),
+		WithFs(afero.NewMemMapFs()),
+		WithExecutor(exec),
+		WithOutputHandler(func(line string) { lines = append(lines, line) }),
+	)
+
+	if err := run.Execute(context.Background()); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+
+	assertLines(t, "streamed lines", lines, []string{"PLAY [all]", "TASK [ping]", "ok: [localhost]"})
+
+	if run.Output != "PLAY [all]\nTASK [ping]\nok: [localhost]\n" {
+		t.Errorf("unexpected output %q", run.Output)
+	}
+
+	if run.Status != ansible.StatusSuccessful {
+		t.Errorf("want status %s, got %s", ansible.StatusSuccessful, run.Status)
+	}
+}
+
+func TestExecuteRedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	var lines []string
+
+	config := testConfig(false)
+	config.RedactValues = []string{"s3cr3t-value"}
+	config.VaultPasswords = []ansible.VaultPassword{{ID: "default", Password: "vault-password"}}
+	config.Settings.ExecutionEnvironment.EnvironmentVariables.Set = map[string]string{"API_TOKEN": "api-token-value", "REGION": "us-east-1"}
+
+	exec := newFakeExecutor().withResponse("run "+testHostDir, "msg: s3cr3t-value\nvault: vault-password\ntoken: api-token-value in us-east-1\n", nil)
+	memFs := afero.NewMemMapFs()
+	run := NewRun(testHostDir, config,
+		WithFs(memFs),
+		WithExecutor(exec),
+		WithOutputHandler(func(line string) { lines = append(lines, line) }),
+	)
+
+	if err := run.Execute(context.Background()); err != nil {
+		t.Fatalf("execute failed: %v", err)
+	}
+
+	want := []string{"msg: (redacted)", "vault: (redacted)", "token: (redacted) in us-east-1"}
+	assertLines(t, "streamed lines", lines, want)
+	assertLines(t, "output", strings.Split(strings.TrimSuffix(run.Output, "\n"), "\n"), want)
+
+	artifact := `{"status":"successful","plays":[{"name":"Test","tasks":[{"task":"Use s3cr3t-value","host":"a","res":{"msg":"s3cr3t-value"}}]}]}`
+	if err := afero.WriteFile(memFs, run.hostJoin(playbookArtifactFilename), []byte(artifact), filePermissions); err != nil {
+		t.Fatalf("failed to write playbook artifact: %v", err)
+	}
+
+	queries := map[string]ansible.PlaybookArtifactQuery{"msg": {JQFilter: ".plays[0].tasks[0].res"}}
+	if err := run.Query(queries); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+
+	assertLines(t, "query results", queries["msg"].Results, []string{`{"msg":"(redacted)"}`})
+
+	parsed, err := run.PlaybookArtifact()
+	if err != nil {
+		t.Fatalf("failed to parse playbook artifact: %v", err)
+	}
+
+	results := parsed.TaskResults()
+	if len(results) != 1 {
+		t.Fatalf("want 1 task result, got %d", len(results))
+	}
+
+	for _, result := range results {
+		if result.Name != "Use (redacted)" || result.Message != "(redacted)" {
+			t.Errorf("want task result masked, got %+v", result)
+		}
+	}
+
+	if got := run.Redact("--extra-vars s3cr3t-value"); got != "--extra-vars (redacted)" {
+		t.Errorf("unexpected redacted string %q", got)
+	}
+}
+
 func TestResolveNavigatorBinary(t *testing.T) {
 	t.Parallel()
 
@@ -418,6 +1122,10 @@ func TestRunDoesNotMutateConfig(t *testing.T) {
 		}
 	}
 
+	if err := afero.WriteFile(memFs, "/work/example-1.0.0.tar.gz", nil, filePermissions); err != nil {
+		t.Fatalf("failed to create requirements source: %v", err)
+	}
+
 	exec := newFakeExecutor().
 		withProgram(ContainerEnginePodman.String(), ContainerEngineDocker.String(), ansible.PlaybookProgram, Program).
 		withResponse(Program+" --version", Program+" 26.6.0", nil)
@@ -429,7 +1137,7 @@ func TestRunDoesNotMutateConfig(t *testing.T) {
 		t.Fatalf("preflight failed: %v", err)
 	}
 
-	if err := run.Setup(); err != nil {
+	if err := run.Setup(context.Background()); err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
@@ -135,6 +135,9 @@ type ExecutionEnvironment struct {
 	EnvironmentVariables EnvironmentVariables
 	VolumeMounts         []VolumeMount
 	ContainerOptions     []string
+	// RequireDigest rejects images not pinned by digest during preflight. It is
+	// not an ansible-navigator setting.
+	RequireDigest bool
 }
 
 type Settings struct {
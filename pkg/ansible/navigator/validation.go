@@ -2,6 +2,10 @@ package navigator
 
 import (
 	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 	_ "time/tzdata" // embedded copy of the timezone database
 
@@ -36,3 +40,79 @@ func ValidateContainerImageName(image string) error {
 
 	return nil
 }
+
+// ValidateProjectFilePath rejects paths that would leave the project directory
+// or replace the playbook.
+func ValidateProjectFilePath(filePath string) error {
+	if len(filePath) == 0 {
+		return fmt.Errorf("%w, project file path must not be empty", ansible.ErrValidation)
+	}
+
+	if strings.Contains(filePath, `\`) || strings.HasSuffix(filePath, "/") || !filepath.IsLocal(filepath.FromSlash(filePath)) {
+		return fmt.Errorf("%w, project file path must be a relative file path within the project directory", ansible.ErrValidation)
+	}
+
+	if path.Clean(filePath) == playbookFilename {
+		return fmt.Errorf("%w, project file path must not be '%s', the playbook", ansible.ErrValidation, playbookFilename)
+	}
+
+	return nil
+}
+
+// ValidateFileMode accepts octal permission bits, such as '0644'. A zero mode
+// is rejected, it would leave the file unreadable and the project file treats
+// it as unset.
+func ValidateFileMode(mode string) error {
+	if len(mode) < 3 || len(mode) > 4 {
+		return fmt.Errorf("%w, file mode must be three or four octal digits", ansible.ErrValidation)
+	}
+
+	bits, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil {
+		return fmt.Errorf("%w, file mode must be octal, %w", ansible.ErrValidation, err)
+	}
+
+	if bits > 0o777 {
+		return fmt.Errorf("%w, file mode must only set permission bits", ansible.ErrValidation)
+	}
+
+	if bits == 0 {
+		return fmt.Errorf("%w, file mode must set at least one permission bit", ansible.ErrValidation)
+	}
+
+	return nil
+}
+
+func ValidateCancelGracePeriod(gracePeriod string) error {
+	duration, err := time.ParseDuration(gracePeriod)
+	if err != nil {
+		return fmt.Errorf("%w, failed to parse cancel grace period, %w", ansible.ErrValidation, err)
+	}
+
+	if duration < 0 {
+		return fmt.Errorf("%w, cancel grace period must not be negative", ansible.ErrValidation)
+	}
+
+	return nil
+}
+
+func ValidateImagePinned(image string) error {
+	if !ImageIsPinned(image) {
+		return fmt.Errorf("%w, image '%s' is not pinned by digest, such as 'image@sha256:...'", ansible.ErrValidation, image)
+	}
+
+	return nil
+}
+
+func ValidateBackoff(backoff string) error {
+	duration, err := time.ParseDuration(backoff)
+	if err != nil {
+		return fmt.Errorf("%w, failed to parse backoff duration, %w", ansible.ErrValidation, err)
+	}
+
+	if duration <= 0 {
+		return fmt.Errorf("%w, backoff duration must be positive", ansible.ErrValidation)
+	}
+
+	return nil
+}
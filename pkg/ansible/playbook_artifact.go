@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	jq "github.com/itchyny/gojq"
 )
@@ -16,45 +19,328 @@ func (s PlaybookStdout) String() string {
 }
 
 type PlaybookArtifact struct {
-	Status Status
-	Stdout PlaybookStdout
+	Status    Status
+	Stdout    PlaybookStdout
+	Plays     []PlaybookPlay
+	HostStats map[string]HostStats
+	Timeline  Timeline
+}
+
+type PlaybookPlay struct {
+	Name  string
+	Tasks []TaskResult
+}
+
+type TaskStatus string
+
+const (
+	TaskStatusOK          TaskStatus = "ok"
+	TaskStatusChanged     TaskStatus = "changed"
+	TaskStatusFailed      TaskStatus = "failed"
+	TaskStatusIgnored     TaskStatus = "ignored"
+	TaskStatusSkipped     TaskStatus = "skipped"
+	TaskStatusUnreachable TaskStatus = "unreachable"
+)
+
+func (s TaskStatus) String() string {
+	return string(s)
+}
+
+type TaskStatuses []TaskStatus
+
+func (s TaskStatuses) Strings() []string {
+	output := make([]string, 0, len(s))
+	for _, element := range s {
+		output = append(output, element.String())
+	}
+
+	return output
+}
+
+func AllTaskStatuses() TaskStatuses {
+	return TaskStatuses{
+		TaskStatusOK,
+		TaskStatusChanged,
+		TaskStatusFailed,
+		TaskStatusIgnored,
+		TaskStatusSkipped,
+		TaskStatusUnreachable,
+	}
+}
+
+// TaskResult is the outcome of a single task on a single host.
+type TaskResult struct {
+	Play    string
+	Name    string
+	Action  string
+	Host    string
+	Status  TaskStatus
+	Changed bool
+	// Message is the 'msg' of the result, such as the reason a task failed.
+	Message string
+	// Duration is zero when the job event has no start and end timestamps.
+	Duration time.Duration
+}
+
+// HostStats mirrors the counters of the PLAY RECAP.
+type HostStats struct {
+	OK          int64
+	Changed     int64
+	Unreachable int64
+	Failed      int64
+	Skipped     int64
+	Rescued     int64
+	Ignored     int64
+}
+
+// TaskResults flattens the tasks of every play in run order.
+func (a *PlaybookArtifact) TaskResults() []TaskResult {
+	var results []TaskResult
+	for _, play := range a.Plays {
+		results = append(results, play.Tasks...)
+	}
+
+	return results
 }
 
 type PlaybookArtifactQuery struct {
 	JQFilter string
 	Raw      bool
 	Results  []string
+	// Values are the decoded results, JSON compatible values as produced by jq.
+	Values []any
 }
 
 type playbookArtifactFormat struct {
-	Status string   `json:"status"`
-	Stdout []string `json:"stdout"`
+	Status string       `json:"status"`
+	Stdout []string     `json:"stdout"`
+	Plays  []playFormat `json:"plays"`
 }
 
+type playFormat struct {
+	Name  string       `json:"name"`
+	Tasks []taskFormat `json:"tasks"`
+}
+
+type taskFormat struct {
+	Task         string         `json:"task"`
+	TaskUUID     string         `json:"task_uuid"`
+	Action       string         `json:"task_action"`
+	Host         string         `json:"host"`
+	IgnoreErrors bool           `json:"ignore_errors"`
+	Res          *taskResFormat `json:"res"`
+	Start        string         `json:"start"`
+	End          string         `json:"end"`
+}
+
+type taskResFormat struct {
+	Changed     bool            `json:"changed"`
+	Failed      bool            `json:"failed"`
+	Skipped     bool            `json:"skipped"`
+	Unreachable bool            `json:"unreachable"`
+	Msg         json.RawMessage `json:"msg"`
+}
+
+var (
+	ansiEscape     = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	playRecapStats = regexp.MustCompile(`^(\S+)\s+:\s+((?:\w+=\d+\s*)+)$`)
+)
+
 func ParsePlaybookArtifact(data []byte) (*PlaybookArtifact, error) {
 	var format playbookArtifactFormat
 	if err := json.Unmarshal(data, &format); err != nil {
 		return nil, fmt.Errorf("failed to parse playbook artifact, %w", err)
 	}
 
-	return &PlaybookArtifact{
+	artifact := &PlaybookArtifact{
 		Status: ParseStatus(format.Status),
 		Stdout: PlaybookStdout(format.Stdout),
-	}, nil
+		Plays:  make([]PlaybookPlay, 0, len(format.Plays)),
+	}
+
+	for _, playFormat := range format.Plays {
+		play := PlaybookPlay{Name: playFormat.Name}
+
+		for _, taskFormat := range playFormat.Tasks {
+			// Tasks still in progress when the run ended have no result.
+			if taskFormat.Res == nil {
+				continue
+			}
+
+			play.Tasks = append(play.Tasks, TaskResult{
+				Play:     playFormat.Name,
+				Name:     taskFormat.Task,
+				Action:   taskFormat.Action,
+				Host:     taskFormat.Host,
+				Status:   taskFormat.status(),
+				Changed:  taskFormat.Res.Changed,
+				Message:  taskFormat.Res.message(),
+				Duration: taskFormat.duration(),
+			})
+		}
+
+		artifact.Plays = append(artifact.Plays, play)
+	}
+
+	artifact.HostStats = parsePlayRecap(artifact.Stdout)
+	if artifact.HostStats == nil {
+		artifact.HostStats = countHostStats(artifact.TaskResults())
+	}
+
+	artifact.Timeline = parseTimeline(format.Plays)
+
+	return artifact, nil
+}
+
+func (t taskFormat) status() TaskStatus {
+	switch {
+	case t.Res.Unreachable:
+		return TaskStatusUnreachable
+	case t.Res.Failed && t.IgnoreErrors:
+		return TaskStatusIgnored
+	case t.Res.Failed:
+		return TaskStatusFailed
+	case t.Res.Skipped:
+		return TaskStatusSkipped
+	case t.Res.Changed:
+		return TaskStatusChanged
+	}
+
+	return TaskStatusOK
+}
+
+// message keeps a 'msg' that is not a string, such as a list, as JSON.
+func (r taskResFormat) message() string {
+	if len(r.Msg) == 0 {
+		return ""
+	}
+
+	var msg string
+	if err := json.Unmarshal(r.Msg, &msg); err == nil {
+		return msg
+	}
+
+	return string(r.Msg)
 }
 
-func QueryPlaybookArtifact(data []byte, query PlaybookArtifactQuery) ([]string, error) {
+func (t taskFormat) duration() time.Duration {
+	start, startOK := parseEventTime(t.Start)
+	end, endOK := parseEventTime(t.End)
+
+	if !startOK || !endOK {
+		return 0
+	}
+
+	return end.Sub(start)
+}
+
+// parsePlayRecap returns nil when stdout has no recap, such as when a
+// non-default stdout callback is configured.
+func parsePlayRecap(stdout PlaybookStdout) map[string]HostStats {
+	var stats map[string]HostStats
+
+	inRecap := false
+
+	for _, line := range stdout {
+		line = strings.TrimSpace(ansiEscape.ReplaceAllString(line, ""))
+
+		if strings.HasPrefix(line, "PLAY RECAP") {
+			inRecap = true
+			stats = map[string]HostStats{}
+
+			continue
+		}
+
+		if !inRecap {
+			continue
+		}
+
+		match := playRecapStats.FindStringSubmatch(line)
+		if match == nil {
+			inRecap = false
+
+			continue
+		}
+
+		stats[match[1]] = parseHostStats(match[2])
+	}
+
+	return stats
+}
+
+func parseHostStats(counters string) HostStats {
+	var stats HostStats
+
+	fields := map[string]*int64{
+		"ok":          &stats.OK,
+		"changed":     &stats.Changed,
+		"unreachable": &stats.Unreachable,
+		"failed":      &stats.Failed,
+		"skipped":     &stats.Skipped,
+		"rescued":     &stats.Rescued,
+		"ignored":     &stats.Ignored,
+	}
+
+	for counter := range strings.FieldsSeq(counters) {
+		name, value, _ := strings.Cut(counter, "=")
+		if field, ok := fields[name]; ok {
+			*field, _ = strconv.ParseInt(value, 10, 64)
+		}
+	}
+
+	return stats
+}
+
+// countHostStats approximates the recap from task results. The job events
+// carry no marker for a failure recovered by a rescue section, Ansible only
+// counts those in its stats, so rescued stays zero and such a failure counts
+// as failed.
+func countHostStats(results []TaskResult) map[string]HostStats {
+	stats := map[string]HostStats{}
+
+	for _, result := range results {
+		hostStats := stats[result.Host]
+
+		switch result.Status {
+		case TaskStatusOK:
+			hostStats.OK++
+		case TaskStatusChanged:
+			hostStats.OK++
+			hostStats.Changed++
+		case TaskStatusIgnored:
+			hostStats.OK++
+			hostStats.Ignored++
+		case TaskStatusFailed:
+			hostStats.Failed++
+		case TaskStatusSkipped:
+			hostStats.Skipped++
+		case TaskStatusUnreachable:
+			hostStats.Unreachable++
+		}
+
+		stats[result.Host] = hostStats
+	}
+
+	return stats
+}
+
+// QueryPlaybookArtifact returns the query with its results set, both as JSON
+// strings and as decoded values.
+func QueryPlaybookArtifact(data []byte, query PlaybookArtifactQuery) (PlaybookArtifactQuery, error) {
 	var blob any
 	if err := json.Unmarshal(data, &blob); err != nil {
-		return nil, fmt.Errorf("failed to parse JSON, %w", err)
+		return query, fmt.Errorf("failed to parse JSON, %w", err)
 	}
 
 	parsed, err := jq.Parse(query.JQFilter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse JQ filter, %w", err)
+		return query, fmt.Errorf("failed to parse JQ filter, %w", err)
 	}
 
-	var results []string
+	var (
+		results []string
+		values  []any
+	)
 
 	iter := parsed.Run(blob)
 	for {
@@ -69,18 +355,22 @@ func QueryPlaybookArtifact(data []byte, query PlaybookArtifactQuery) ([]string,
 				break
 			}
 
-			return nil, fmt.Errorf("JQ failed, %w", err)
+			return query, fmt.Errorf("JQ failed, %w", err)
 		}
 
 		result, err := jqValueString(value, query.Raw)
 		if err != nil {
-			return nil, err
+			return query, err
 		}
 
 		results = append(results, result)
+		values = append(values, value)
 	}
 
-	return results, nil
+	query.Results = results
+	query.Values = values
+
+	return query, nil
 }
 
 func jqValueString(value any, raw bool) (string, error) {
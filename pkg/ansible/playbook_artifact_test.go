@@ -1,6 +1,7 @@
 package ansible_test
 
 import (
+	"maps"
 	"slices"
 	"testing"
 
@@ -84,3 +85,119 @@ func TestParsePlaybookArtifact(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePlaybookArtifactResults(t *testing.T) {
+	t.Parallel()
+
+	plays := `"plays":[{"name":"Test","tasks":[
+		{"task":"Ping","task_action":"ansible.builtin.ping","host":"a","res":{"changed":false}},
+		{"task":"Copy","task_action":"ansible.builtin.copy","host":"a","res":{"changed":true}},
+		{"task":"Copy","task_action":"ansible.builtin.copy","host":"b","res":{"failed":true,"changed":true}},
+		{"task":"Check","task_action":"ansible.builtin.command","host":"a","ignore_errors":true,"res":{"failed":true}},
+		{"task":"Skip","task_action":"ansible.builtin.debug","host":"a","res":{"skipped":true}},
+		{"task":"Ping","task_action":"ansible.builtin.ping","host":"c","res":{"unreachable":true}},
+		{"task":"Pending","task_action":"ansible.builtin.pause","host":"a"}
+	]}]`
+
+	wantTasks := []ansible.TaskResult{
+		{Play: "Test", Name: "Ping", Action: "ansible.builtin.ping", Host: "a", Status: ansible.TaskStatusOK},
+		{Play: "Test", Name: "Copy", Action: "ansible.builtin.copy", Host: "a", Status: ansible.TaskStatusChanged, Changed: true},
+		{Play: "Test", Name: "Copy", Action: "ansible.builtin.copy", Host: "b", Status: ansible.TaskStatusFailed, Changed: true},
+		{Play: "Test", Name: "Check", Action: "ansible.builtin.command", Host: "a", Status: ansible.TaskStatusIgnored},
+		{Play: "Test", Name: "Skip", Action: "ansible.builtin.debug", Host: "a", Status: ansible.TaskStatusSkipped},
+		{Play: "Test", Name: "Ping", Action: "ansible.builtin.ping", Host: "c", Status: ansible.TaskStatusUnreachable},
+	}
+
+	tests := map[string]struct {
+		input     string
+		hostStats map[string]ansible.HostStats
+	}{
+		"play_recap": {
+			input: `{"status":"failed","stdout":[
+				"PLAY RECAP *********************************************************************",
+				"\u001b[0;33ma\u001b[0m                          : \u001b[0;32mok=3   \u001b[0m \u001b[0;33mchanged=1   \u001b[0m unreachable=0    failed=0    \u001b[0;36mskipped=1   \u001b[0m rescued=2    \u001b[1;35mignored=1   \u001b[0m",
+				"b                          : ok=0    changed=1    unreachable=0    failed=1    skipped=0    rescued=0    ignored=0   ",
+				"c                          : ok=0    changed=0    unreachable=1    failed=0    skipped=0    rescued=0    ignored=0   ",
+				""
+			],` + plays + `}`,
+			hostStats: map[string]ansible.HostStats{
+				"a": {OK: 3, Changed: 1, Skipped: 1, Rescued: 2, Ignored: 1},
+				"b": {Changed: 1, Failed: 1},
+				"c": {Unreachable: 1},
+			},
+		},
+		"counted_from_tasks": {
+			input: `{"status":"failed","stdout":[],` + plays + `}`,
+			hostStats: map[string]ansible.HostStats{
+				"a": {OK: 3, Changed: 1, Skipped: 1, Ignored: 1},
+				"b": {Failed: 1},
+				"c": {Unreachable: 1},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ansible.ParsePlaybookArtifact([]byte(test.input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(got.TaskResults(), wantTasks) {
+				t.Errorf("task results: expected %+v, got %+v", wantTasks, got.TaskResults())
+			}
+
+			if !maps.Equal(got.HostStats, test.hostStats) {
+				t.Errorf("host stats: expected %+v, got %+v", test.hostStats, got.HostStats)
+			}
+		})
+	}
+}
+
+func TestQueryPlaybookArtifact(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(`{"status":"successful","stdout":["a","b"],"plays":[]}`)
+
+	tests := map[string]struct {
+		query   ansible.PlaybookArtifactQuery
+		results []string
+		values  []any
+	}{
+		"single": {
+			query:   ansible.PlaybookArtifactQuery{JQFilter: ".status"},
+			results: []string{`"successful"`},
+			values:  []any{"successful"},
+		},
+		"raw": {
+			query:   ansible.PlaybookArtifactQuery{JQFilter: ".stdout[]", Raw: true},
+			results: []string{"a", "b"},
+			values:  []any{"a", "b"},
+		},
+		"none": {
+			query: ansible.PlaybookArtifactQuery{JQFilter: "empty"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ansible.QueryPlaybookArtifact(data, test.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			// results of a query without output stay nil, and are null in state
+			if !slices.Equal(got.Results, test.results) || (got.Results == nil) != (test.results == nil) {
+				t.Errorf("results: expected %#v, got %#v", test.results, got.Results)
+			}
+
+			if !slices.Equal(got.Values, test.values) {
+				t.Errorf("values: expected %v, got %v", test.values, got.Values)
+			}
+		})
+	}
+}
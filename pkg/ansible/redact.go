@@ -0,0 +1,248 @@
+package ansible
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	Redacted = "(redacted)"
+
+	// MinRedactLength leaves out values so short that masking them would
+	// mangle unrelated output.
+	MinRedactLength = 4
+)
+
+func sensitiveEnvVarNameParts() []string {
+	return []string{
+		"PASSWORD",
+		"PASSWD",
+		"PASSPHRASE",
+		"SECRET",
+		"TOKEN",
+		"CREDENTIAL",
+		"PRIVATE_KEY",
+		"API_KEY",
+		"ACCESS_KEY",
+	}
+}
+
+// IsSensitiveEnvVarName reports whether the name suggests the value is a
+// secret, such as 'AWS_SECRET_ACCESS_KEY' or 'VAULT_TOKEN'.
+func IsSensitiveEnvVarName(name string) bool {
+	name = strings.ToUpper(name)
+
+	for _, part := range sensitiveEnvVarNameParts() {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ShortValues counts the values too short to be masked, such as a vault
+// password of a few characters. Empty values are not counted.
+func ShortValues(values ...string) int {
+	var count int
+
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" && len(value) < MinRedactLength {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Redactor masks known sensitive values. A nil Redactor masks nothing.
+type Redactor struct {
+	replacer *strings.Replacer
+}
+
+// NewRedactor masks each value, and each line of a multi-line value such as a
+// private key, as output may wrap or escape the value line by line.
+func NewRedactor(values ...string) *Redactor {
+	var secrets []string
+
+	for _, value := range values {
+		secrets = append(secrets, strings.TrimSpace(value))
+
+		if strings.Contains(value, "\n") {
+			for line := range strings.Lines(value) {
+				secrets = append(secrets, strings.TrimSpace(line))
+			}
+		}
+	}
+
+	secrets = slices.DeleteFunc(secrets, func(secret string) bool {
+		return len(secret) < MinRedactLength
+	})
+
+	// At the same position the replacer prefers the earlier argument, so the
+	// longest value wins over a line within it.
+	slices.SortFunc(secrets, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), strings.Compare(a, b))
+	})
+	secrets = slices.Compact(secrets)
+
+	if len(secrets) == 0 {
+		return nil
+	}
+
+	pairs := make([]string, 0, 2*len(secrets)) //nolint:mnd
+	for _, secret := range secrets {
+		pairs = append(pairs, secret, Redacted)
+	}
+
+	return &Redactor{replacer: strings.NewReplacer(pairs...)}
+}
+
+func (r *Redactor) Redact(s string) string {
+	if r == nil {
+		return s
+	}
+
+	return r.replacer.Replace(s)
+}
+
+// RedactQuery masks strings within the decoded query values, then encodes the
+// results again, so that they remain valid JSON.
+func (r *Redactor) RedactQuery(query PlaybookArtifactQuery) (PlaybookArtifactQuery, error) {
+	if r == nil || len(query.Values) == 0 {
+		return query, nil
+	}
+
+	results := make([]string, 0, len(query.Values))
+	values := make([]any, 0, len(query.Values))
+
+	for _, value := range query.Values {
+		value = r.redactValue(value)
+
+		result, err := jqValueString(value, query.Raw)
+		if err != nil {
+			return query, err
+		}
+
+		results = append(results, result)
+		values = append(values, value)
+	}
+
+	query.Results = results
+	query.Values = values
+
+	return query, nil
+}
+
+func (r *Redactor) redactValue(value any) any {
+	switch typed := value.(type) {
+	case string:
+		return r.Redact(typed)
+	case []any:
+		redacted := make([]any, 0, len(typed))
+		for _, element := range typed {
+			redacted = append(redacted, r.redactValue(element))
+		}
+
+		return redacted
+	case map[string]any:
+		redacted := make(map[string]any, len(typed))
+		for key, element := range typed {
+			redacted[r.Redact(key)] = r.redactValue(element)
+		}
+
+		return redacted
+	default:
+		return value
+	}
+}
+
+// RedactJSON masks strings within the decoded document, object keys included,
+// then encodes it again. Numbers are kept as written. A nil Redactor returns
+// the document unchanged.
+func (r *Redactor) RedactJSON(data []byte) ([]byte, error) {
+	if r == nil {
+		return data, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed to decode JSON, %w", err)
+	}
+
+	var redacted bytes.Buffer
+
+	encoder := json.NewEncoder(&redacted)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(r.redactValue(value)); err != nil {
+		return nil, fmt.Errorf("failed to encode JSON, %w", err)
+	}
+
+	return bytes.TrimSuffix(redacted.Bytes(), []byte("\n")), nil
+}
+
+// RedactArtifact returns a copy of the artifact with the strings it reports,
+// such as play, task and host names and result messages, masked. Redacting encoded
+// output instead would miss values the encoding escapes.
+func (r *Redactor) RedactArtifact(artifact *PlaybookArtifact) *PlaybookArtifact {
+	if r == nil {
+		return artifact
+	}
+
+	redacted := *artifact
+	redacted.Stdout = make(PlaybookStdout, 0, len(artifact.Stdout))
+
+	for _, line := range artifact.Stdout {
+		redacted.Stdout = append(redacted.Stdout, r.Redact(line))
+	}
+
+	redacted.Plays = make([]PlaybookPlay, 0, len(artifact.Plays))
+	for _, play := range artifact.Plays {
+		tasks := make([]TaskResult, 0, len(play.Tasks))
+		for _, result := range play.Tasks {
+			result.Play = r.Redact(result.Play)
+			result.Name = r.Redact(result.Name)
+			result.Action = r.Redact(result.Action)
+			result.Host = r.Redact(result.Host)
+			result.Message = r.Redact(result.Message)
+			tasks = append(tasks, result)
+		}
+
+		redacted.Plays = append(redacted.Plays, PlaybookPlay{Name: r.Redact(play.Name), Tasks: tasks})
+	}
+
+	redacted.HostStats = make(map[string]HostStats, len(artifact.HostStats))
+	for host, stats := range artifact.HostStats {
+		redacted.HostStats[r.Redact(host)] = stats
+	}
+
+	redacted.Timeline = r.redactTimeline(artifact.Timeline)
+
+	return &redacted
+}
+
+func (r *Redactor) redactTimeline(timeline Timeline) Timeline {
+	plays := make([]PlayTiming, 0, len(timeline.Plays))
+	for _, play := range timeline.Plays {
+		tasks := make([]TaskTiming, 0, len(play.Tasks))
+		for _, task := range play.Tasks {
+			task.Play = r.Redact(task.Play)
+			task.Name = r.Redact(task.Name)
+			tasks = append(tasks, task)
+		}
+
+		play.Name = r.Redact(play.Name)
+		play.Tasks = tasks
+		plays = append(plays, play)
+	}
+
+	return Timeline{Plays: plays}
+}
@@ -0,0 +1,93 @@
+package ansible
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const RunnerProgram = "ansible-runner"
+
+// RunnerEvent is a job event, as written by ansible-runner to
+// artifacts/<ident>/job_events.
+type RunnerEvent struct {
+	Counter   int            `json:"counter"`
+	Event     string         `json:"event"`
+	EventData map[string]any `json:"event_data"`
+}
+
+func ParseRunnerEvent(data []byte) (RunnerEvent, error) {
+	var event RunnerEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		return event, fmt.Errorf("failed to parse ansible-runner job event, %w", err)
+	}
+
+	return event, nil
+}
+
+type runnerArtifactFormat struct {
+	Status string           `json:"status"`
+	Stdout []string         `json:"stdout"`
+	Plays  []map[string]any `json:"plays"`
+}
+
+// RunnerPlaybookArtifact assembles the job events of an ansible-runner run into
+// the playbook artifact ansible-navigator would have saved. As with navigator,
+// plays carry the event data of their start event, and tasks the event data of
+// their result, so artifact queries see the same fields.
+func RunnerPlaybookArtifact(status Status, stdout string, events []RunnerEvent) ([]byte, error) {
+	events = slices.SortedFunc(slices.Values(events), func(a, b RunnerEvent) int {
+		return cmp.Compare(a.Counter, b.Counter)
+	})
+
+	format := runnerArtifactFormat{
+		Status: string(status),
+		Stdout: strings.Split(strings.TrimSuffix(stdout, "\n"), "\n"),
+		Plays:  []map[string]any{},
+	}
+
+	playIndex := map[any]int{}
+	tasks := [][]map[string]any{}
+
+	for _, event := range events {
+		switch event.Event {
+		case "playbook_on_play_start":
+			play := map[string]any{}
+			maps.Copy(play, event.EventData)
+			play["name"] = event.EventData["play"]
+
+			playIndex[event.EventData["play_uuid"]] = len(format.Plays)
+			format.Plays = append(format.Plays, play)
+			tasks = append(tasks, []map[string]any{})
+		case "runner_on_ok", "runner_on_failed", "runner_on_skipped", "runner_on_unreachable":
+			index, ok := playIndex[event.EventData["play_uuid"]]
+			if !ok {
+				continue
+			}
+
+			task := map[string]any{}
+			maps.Copy(task, event.EventData)
+
+			// Skipped events may omit the result, which the parser requires.
+			if _, ok := task["res"]; !ok && event.Event == "runner_on_skipped" {
+				task["res"] = map[string]any{"changed": false, "skipped": true}
+			}
+
+			tasks[index] = append(tasks[index], task)
+		}
+	}
+
+	for index, play := range format.Plays {
+		play["tasks"] = tasks[index]
+	}
+
+	data, err := json.Marshal(format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build playbook artifact, %w", err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,75 @@
+package ansible_test
+
+import (
+	"testing"
+
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+)
+
+func TestRunnerPlaybookArtifact(t *testing.T) {
+	t.Parallel()
+
+	var events []ansible.RunnerEvent
+
+	// Out of order, as directory listings are not sorted by counter.
+	for _, data := range []string{
+		`{"counter":4,"event":"runner_on_skipped","event_data":{"play":"Test","play_uuid":"p1","task":"Skip","task_action":"ansible.builtin.debug","host":"b"}}`,
+		`{"counter":1,"event":"playbook_on_start","event_data":{}}`,
+		`{"counter":2,"event":"playbook_on_play_start","event_data":{"play":"Test","play_uuid":"p1"}}`,
+		`{"counter":3,"event":"runner_on_ok","event_data":{"play":"Test","play_uuid":"p1","task":"Change","task_action":"ansible.builtin.command","host":"a","res":{"changed":true}}}`,
+		`{"counter":5,"event":"runner_on_failed","event_data":{"play":"Test","play_uuid":"p1","task":"Fail","task_action":"ansible.builtin.fail","host":"a","ignore_errors":true,"res":{"failed":true}}}`,
+	} {
+		event, err := ansible.ParseRunnerEvent([]byte(data))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		events = append(events, event)
+	}
+
+	stdout := "PLAY [Test] ***\n\nPLAY RECAP ***\na : ok=2 changed=1 unreachable=0 failed=0 skipped=0 rescued=0 ignored=1\nb : ok=0 changed=0 unreachable=0 failed=0 skipped=1 rescued=0 ignored=0\n"
+
+	data, err := ansible.RunnerPlaybookArtifact(ansible.StatusSuccessful, stdout, events)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	artifact, err := ansible.ParsePlaybookArtifact(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if artifact.Status != ansible.StatusSuccessful {
+		t.Errorf("expected status %q, got %q", ansible.StatusSuccessful, artifact.Status)
+	}
+
+	if got := artifact.HostStats["a"]; got.OK != 2 || got.Changed != 1 || got.Ignored != 1 {
+		t.Errorf("unexpected host stats for a: %+v", got)
+	}
+
+	want := []ansible.TaskResult{
+		{Play: "Test", Name: "Change", Action: "ansible.builtin.command", Host: "a", Status: ansible.TaskStatusChanged, Changed: true},
+		{Play: "Test", Name: "Skip", Action: "ansible.builtin.debug", Host: "b", Status: ansible.TaskStatusSkipped},
+		{Play: "Test", Name: "Fail", Action: "ansible.builtin.fail", Host: "a", Status: ansible.TaskStatusIgnored},
+	}
+
+	got := artifact.TaskResults()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d task results, got %d: %+v", len(want), len(got), got)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("task result %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+
+	query, err := ansible.QueryPlaybookArtifact(data, ansible.PlaybookArtifactQuery{JQFilter: `.plays[0].tasks[0].res.changed`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(query.Results) != 1 || query.Results[0] != "true" {
+		t.Errorf("expected query result true, got %v", query.Results)
+	}
+}
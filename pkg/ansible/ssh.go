@@ -4,18 +4,110 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"math"
+	"strings"
+	"time"
 
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/knownhosts"
 )
 
+const (
+	SSHCertificateSuffix = "-cert.pub"
+
+	// SSHProxyHostAlias names the proxy in the generated ssh_config.
+	SSHProxyHostAlias = "ssh-proxy"
+
+	sshCertAuthorityMarker = "cert-authority"
+	sshRevokedMarker       = "revoked"
+)
+
+// PrivateKey optionally includes an OpenSSH user certificate, written next to
+// the key so that ssh loads it automatically. An encrypted key requires its
+// passphrase.
 type PrivateKey struct {
-	Name string
-	Data string
+	Name        string
+	Data        string
+	Passphrase  string
+	Certificate string
+}
+
+// parseSSHPrivateKey decrypts the private key when a passphrase is given.
+func parseSSHPrivateKey(privateKey string, passphrase string) (any, error) {
+	if passphrase == "" {
+		key, err := ssh.ParseRawPrivateKey([]byte(privateKey))
+		if err != nil {
+			return nil, fmt.Errorf("%w, failed to parse SSH private key, %w", ErrValidation, err)
+		}
+
+		return key, nil
+	}
+
+	key, err := ssh.ParseRawPrivateKeyWithPassphrase([]byte(privateKey), []byte(passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("%w, failed to decrypt SSH private key, %w", ErrValidation, err)
+	}
+
+	return key, nil
+}
+
+func sshSigner(privateKey string, passphrase string) (ssh.Signer, error) {
+	key, err := parseSSHPrivateKey(privateKey, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w, failed to parse SSH private key, %w", ErrValidation, err)
+	}
+
+	return signer, nil
 }
 
 type KnownHost = string
 
+// SSHProxy is a bastion host that connections are tunneled through. Its host
+// key is checked against the same known hosts file as the target hosts.
+type SSHProxy struct {
+	Host           string
+	Port           int64
+	User           string
+	PrivateKeyName string
+	KnownHosts     []KnownHost
+}
+
+func (p SSHProxy) IsEmpty() bool {
+	return p.Host == ""
+}
+
+// Config renders an ssh_config file with a single host block for the proxy,
+// named SSHProxyHostAlias.
+func (p SSHProxy) Config(identityFile string, knownHostsFile string) string {
+	port := p.Port
+	if port == 0 {
+		port = DefaultSSHPort
+	}
+
+	lines := []string{
+		"Host " + SSHProxyHostAlias,
+		"  HostName " + p.Host,
+		fmt.Sprintf("  Port %d", port),
+	}
+
+	if p.User != "" {
+		lines = append(lines, "  User "+p.User)
+	}
+
+	if identityFile != "" {
+		lines = append(lines, fmt.Sprintf("  IdentityFile %q", identityFile), "  IdentitiesOnly yes")
+	}
+
+	lines = append(lines, fmt.Sprintf("  UserKnownHostsFile %q", knownHostsFile))
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
 func ParseKnownHosts(r io.Reader) ([]KnownHost, error) {
 	knownHosts := make([]KnownHost, 0)
 	scanner := bufio.NewScanner(r)
@@ -43,12 +135,73 @@ func KnownHostsLine(addresses []string, publicKey string) (string, error) {
 	return knownhosts.Line(addresses, entry), nil
 }
 
+// CheckSSHCertificateValidity reports an error when now is outside of the
+// validity period of the certificate.
+func CheckSSHCertificateValidity(certificate string, now time.Time) error {
+	cert, err := parseSSHCertificate(certificate)
+	if err != nil {
+		return err
+	}
+
+	if validAfter := sshCertificateTime(cert.ValidAfter); now.Before(validAfter) {
+		return fmt.Errorf("SSH certificate is not valid until %s", validAfter.UTC().Format(time.RFC3339))
+	}
+
+	if cert.ValidBefore == ssh.CertTimeInfinity {
+		return nil
+	}
+
+	if validBefore := sshCertificateTime(cert.ValidBefore); !now.Before(validBefore) {
+		return fmt.Errorf("SSH certificate expired at %s", validBefore.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func parseSSHCertificate(certificate string) (*ssh.Certificate, error) {
+	if len(certificate) == 0 {
+		return nil, fmt.Errorf("%w, SSH certificate must not be empty", ErrValidation)
+	}
+
+	key, _, _, rest, err := ssh.ParseAuthorizedKey([]byte(certificate))
+	if err != nil {
+		return nil, fmt.Errorf("%w, failed to parse SSH certificate, %w", ErrValidation, err)
+	}
+
+	if len(strings.TrimSpace(string(rest))) > 0 {
+		return nil, fmt.Errorf("%w, must not include multiple SSH certificates or additional data", ErrValidation)
+	}
+
+	cert, ok := key.(*ssh.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("%w, SSH certificate must be an OpenSSH certificate, not a public key", ErrValidation)
+	}
+
+	if cert.CertType != ssh.UserCert {
+		return nil, fmt.Errorf("%w, SSH certificate must be a user certificate", ErrValidation)
+	}
+
+	return cert, nil
+}
+
+func sshCertificateTime(seconds uint64) time.Time {
+	return time.Unix(int64(min(seconds, math.MaxInt64)), 0) //nolint:gosec
+}
+
 func SSHArgs(acceptNew bool) string {
-	strictHostKeyChecking := "yes"
+	return fmt.Sprintf("-o StrictHostKeyChecking=%s -o UserKnownHostsFile={{ %s }}", sshStrictHostKeyChecking(acceptNew), SSHKnownHostsFileVar)
+}
+
+// SSHProxyArgs extends SSHArgs to tunnel connections through the proxy, which
+// is subject to the same host key checking.
+func SSHProxyArgs(acceptNew bool) string {
+	return fmt.Sprintf(`%s -o ProxyCommand="ssh -F {{ %s }} -o StrictHostKeyChecking=%s -W [%%h]:%%p %s"`, SSHArgs(acceptNew), SSHProxyConfigFileVar, sshStrictHostKeyChecking(acceptNew), SSHProxyHostAlias)
+}
 
+func sshStrictHostKeyChecking(acceptNew bool) string {
 	if acceptNew {
-		strictHostKeyChecking = "accept-new"
+		return "accept-new"
 	}
 
-	return fmt.Sprintf("-o StrictHostKeyChecking=%s -o UserKnownHostsFile={{ %s }}", strictHostKeyChecking, SSHKnownHostsFileVar)
+	return "yes"
 }
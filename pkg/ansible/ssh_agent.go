@@ -0,0 +1,136 @@
+package ansible
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"golang.org/x/crypto/ssh/agent"
+)
+
+const (
+	SSHAuthSockEnvVar = "SSH_AUTH_SOCK"
+
+	// MaxSSHAgentSocketPathLength is the shortest limit on unix socket paths
+	// across supported platforms, set by macOS.
+	MaxSSHAgentSocketPathLength = 103
+)
+
+// SSHAgent serves private keys to ssh from memory over a unix socket, so that
+// decrypted key material is never written to disk.
+type SSHAgent struct {
+	listener net.Listener
+	keyring  agent.Agent
+
+	mu    sync.Mutex
+	conns map[net.Conn]struct{}
+	wg    sync.WaitGroup
+}
+
+// StartSSHAgent listens on socketPath and serves the keys until Close is
+// called. Keys are decrypted with their passphrase, and added along with their
+// certificate when one is set.
+func StartSSHAgent(socketPath string, keys []PrivateKey) (*SSHAgent, error) {
+	if len(socketPath) > MaxSSHAgentSocketPathLength {
+		return nil, fmt.Errorf("SSH agent socket path must not be longer than %d characters, got '%s'", MaxSSHAgentSocketPathLength, socketPath)
+	}
+
+	keyring := agent.NewKeyring()
+
+	for _, key := range keys {
+		addedKey, err := sshAgentKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add private key '%s' to SSH agent, %w", key.Name, err)
+		}
+
+		if err := keyring.Add(addedKey); err != nil {
+			return nil, fmt.Errorf("failed to add private key '%s' to SSH agent, %w", key.Name, err)
+		}
+	}
+
+	listener, err := net.Listen("unix", socketPath) //nolint:noctx
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on SSH agent socket, %w", err)
+	}
+
+	sshAgent := &SSHAgent{
+		listener: listener,
+		keyring:  keyring,
+		conns:    map[net.Conn]struct{}{},
+	}
+
+	sshAgent.wg.Add(1)
+
+	go sshAgent.serve()
+
+	return sshAgent, nil
+}
+
+func sshAgentKey(key PrivateKey) (agent.AddedKey, error) {
+	rawKey, err := parseSSHPrivateKey(key.Data, key.Passphrase)
+	if err != nil {
+		return agent.AddedKey{}, err
+	}
+
+	addedKey := agent.AddedKey{PrivateKey: rawKey, Comment: key.Name}
+
+	if key.Certificate != "" {
+		cert, err := parseSSHCertificate(key.Certificate)
+		if err != nil {
+			return agent.AddedKey{}, err
+		}
+
+		addedKey.Certificate = cert
+	}
+
+	return addedKey, nil
+}
+
+func (a *SSHAgent) serve() {
+	defer a.wg.Done()
+
+	for {
+		conn, err := a.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		a.mu.Lock()
+		a.conns[conn] = struct{}{}
+		a.mu.Unlock()
+
+		a.wg.Add(1)
+
+		go func() {
+			defer a.wg.Done()
+
+			_ = agent.ServeAgent(a.keyring, conn)
+
+			a.mu.Lock()
+			delete(a.conns, conn)
+			a.mu.Unlock()
+
+			_ = conn.Close()
+		}()
+	}
+}
+
+// Close stops the agent, removes the socket and disconnects any clients.
+func (a *SSHAgent) Close() error {
+	err := a.listener.Close()
+
+	// Clients may already be disconnecting on their own.
+	a.mu.Lock()
+	for conn := range a.conns {
+		_ = conn.Close()
+	}
+	a.mu.Unlock()
+
+	a.wg.Wait()
+
+	if err != nil {
+		return fmt.Errorf("failed to stop SSH agent, %w", err)
+	}
+
+	return nil
+}
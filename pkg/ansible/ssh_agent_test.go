@@ -0,0 +1,111 @@
+package ansible_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+	gossh "golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func testSSHAgentKeys(t *testing.T, socketPath string) []string {
+	t.Helper()
+
+	conn, err := net.Dial("unix", socketPath) //nolint:noctx
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	keys, err := agent.NewClient(conn).List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		publicKeys = append(publicKeys, strings.TrimSpace(string(gossh.MarshalAuthorizedKey(key))))
+	}
+
+	return publicKeys
+}
+
+func TestStartSSHAgent(t *testing.T) {
+	t.Parallel()
+
+	publicKey, privateKey := testSSHKeygenWithPassphrase(t, "secret")
+	otherPublicKey, otherPrivateKey := testSSHKeygen(t)
+	cert, _ := testSSHCertificate(t, otherPublicKey, gossh.UserCert, time.Now(), gossh.CertTimeInfinity)
+
+	socketPath := filepath.Join(t.TempDir(), "agent.sock")
+
+	sshAgent, err := ansible.StartSSHAgent(socketPath, []ansible.PrivateKey{
+		{Name: "encrypted", Data: privateKey, Passphrase: "secret"},
+		{Name: "certificate", Data: otherPrivateKey, Certificate: cert},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The keyring serves a key with a certificate only as the certificate.
+	got := testSSHAgentKeys(t, socketPath)
+	want := []string{publicKey, cert}
+
+	slices.Sort(got)
+	slices.Sort(want)
+
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	if err := sshAgent.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := os.Stat(socketPath); !os.IsNotExist(err) {
+		t.Errorf("expected socket to be removed, got %v", err)
+	}
+}
+
+func TestStartSSHAgentErrors(t *testing.T) {
+	t.Parallel()
+
+	_, privateKey := testSSHKeygenWithPassphrase(t, "secret")
+
+	tests := map[string]struct {
+		socketPath string
+		keys       []ansible.PrivateKey
+		want       string
+	}{
+		"passphrase": {
+			keys: []ansible.PrivateKey{{Name: "encrypted", Data: privateKey, Passphrase: "wrong"}},
+			want: "failed to add private key 'encrypted'",
+		},
+		"socket_path": {
+			socketPath: "/" + strings.Repeat("a", ansible.MaxSSHAgentSocketPathLength),
+			want:       "must not be longer than",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			socketPath := test.socketPath
+			if socketPath == "" {
+				socketPath = filepath.Join(t.TempDir(), "agent.sock")
+			}
+
+			_, err := ansible.StartSSHAgent(socketPath, test.keys)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("expected error containing %q, got %v", test.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package ansible
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	DefaultSSHPort               = 22
+	DefaultSSHHostKeyScanBackoff = 2 * time.Second
+
+	sshHostKeyScanUser = "ansible"
+)
+
+var (
+	errHostKeyCaptured = errors.New("host key captured")
+)
+
+func DefaultSSHHostKeyAlgorithms() []string {
+	return []string{
+		ssh.KeyAlgoED25519,
+		ssh.KeyAlgoECDSA256,
+		ssh.KeyAlgoRSASHA512,
+	}
+}
+
+func SSHHostKeyAlgorithms() []string {
+	return []string{
+		ssh.KeyAlgoED25519,
+		ssh.KeyAlgoECDSA256,
+		ssh.KeyAlgoECDSA384,
+		ssh.KeyAlgoECDSA521,
+		ssh.KeyAlgoRSASHA512,
+		ssh.KeyAlgoRSASHA256,
+		ssh.KeyAlgoRSA,
+	}
+}
+
+// SSHHostKeyScan connects to Host:Port once per algorithm and captures the
+// host key offered during the handshake, without authenticating.
+type SSHHostKeyScan struct {
+	Host       string
+	Port       int64
+	Algorithms []string
+	Backoff    time.Duration
+}
+
+func ValidateSSHHostKeyAlgorithm(algorithm string) error {
+	if !slices.Contains(SSHHostKeyAlgorithms(), algorithm) {
+		return fmt.Errorf("%w, host key algorithm must be one of: %s", ErrValidation, strings.Join(SSHHostKeyAlgorithms(), ", "))
+	}
+
+	return nil
+}
+
+func (s SSHHostKeyScan) Address() string {
+	port := s.Port
+	if port == 0 {
+		port = DefaultSSHPort
+	}
+
+	return net.JoinHostPort(s.Host, strconv.FormatInt(port, 10))
+}
+
+// ScanSSHHostKeys returns the host keys offered by the server in authorized
+// keys format. Connection and handshake failures are retried until the context
+// is done, as the server may still be booting. Algorithms the server does not
+// support are skipped, but at least one key must be captured.
+func ScanSSHHostKeys(ctx context.Context, scan SSHHostKeyScan) ([]string, error) {
+	algorithms := scan.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = DefaultSSHHostKeyAlgorithms()
+	}
+
+	backoff := scan.Backoff
+	if backoff == 0 {
+		backoff = DefaultSSHHostKeyScanBackoff
+	}
+
+	address := scan.Address()
+	publicKeys := []string{}
+
+	for _, algorithm := range algorithms {
+		if err := ValidateSSHHostKeyAlgorithm(algorithm); err != nil {
+			return nil, err
+		}
+
+		publicKey, err := scanSSHHostKeyRetry(ctx, address, algorithm, backoff)
+
+		var negotiationErr *ssh.AlgorithmNegotiationError
+		if errors.As(err, &negotiationErr) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s host key of %s, %w", algorithm, address, err)
+		}
+
+		authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+		if !slices.Contains(publicKeys, authorizedKey) {
+			publicKeys = append(publicKeys, authorizedKey)
+		}
+	}
+
+	if len(publicKeys) == 0 {
+		return nil, fmt.Errorf("%s offered none of the host key algorithms: %s", address, strings.Join(algorithms, ", "))
+	}
+
+	return publicKeys, nil
+}
+
+func scanSSHHostKeyRetry(ctx context.Context, address string, algorithm string, backoff time.Duration) (ssh.PublicKey, error) {
+	for {
+		publicKey, err := scanSSHHostKey(ctx, address, algorithm)
+
+		var negotiationErr *ssh.AlgorithmNegotiationError
+		if err == nil || errors.As(err, &negotiationErr) {
+			return publicKey, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.Join(ctx.Err(), err)
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func scanSSHHostKey(ctx context.Context, address string, algorithm string) (ssh.PublicKey, error) {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect, %w", err)
+	}
+	defer conn.Close() //nolint:errcheck
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, fmt.Errorf("failed to set connection deadline, %w", err)
+		}
+	}
+
+	var publicKey ssh.PublicKey
+
+	_, _, _, err = ssh.NewClientConn(conn, address, &ssh.ClientConfig{
+		User:              sshHostKeyScanUser,
+		HostKeyAlgorithms: []string{algorithm},
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			publicKey = key
+
+			return errHostKeyCaptured
+		},
+	})
+	if publicKey != nil {
+		return publicKey, nil
+	}
+
+	return nil, fmt.Errorf("failed to complete handshake, %w", err)
+}
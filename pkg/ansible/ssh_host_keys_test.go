@@ -0,0 +1,178 @@
+package ansible_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gliderlabs/ssh"
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const testSSHHostKeyScanBackoff = 50 * time.Millisecond
+
+func testSSHHostKeyServer(t *testing.T, listener net.Listener) string {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := gossh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := ssh.Server{Handler: func(ssh.Session) {}}
+	server.AddHostKey(signer)
+
+	go server.Serve(listener) //nolint:errcheck
+
+	t.Cleanup(func() {
+		if err := server.Close(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	return strings.TrimSpace(string(gossh.MarshalAuthorizedKey(signer.PublicKey())))
+}
+
+func testSSHHostKeyListener(t *testing.T) (net.Listener, int64) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0") //nolint:noctx
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr, ok := listener.Addr().(*net.TCPAddr)
+	if !ok {
+		t.Fatal("unexpected listener address type")
+	}
+
+	return listener, int64(addr.Port)
+}
+
+func TestScanSSHHostKeys(t *testing.T) {
+	t.Parallel()
+
+	listener, port := testSSHHostKeyListener(t)
+	publicKey := testSSHHostKeyServer(t, listener)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	got, err := ansible.ScanSSHHostKeys(ctx, ansible.SSHHostKeyScan{
+		Host:       "127.0.0.1",
+		Port:       port,
+		Algorithms: []string{gossh.KeyAlgoECDSA256, gossh.KeyAlgoED25519},
+		Backoff:    testSSHHostKeyScanBackoff,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !slices.Equal(got, []string{publicKey}) {
+		t.Errorf("expected %v, got %v", []string{publicKey}, got)
+	}
+}
+
+func TestScanSSHHostKeysWaitsForServer(t *testing.T) {
+	t.Parallel()
+
+	listener, port := testSSHHostKeyListener(t)
+	if err := listener.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	publicKeys := make(chan string, 1)
+
+	go func() {
+		time.Sleep(4 * testSSHHostKeyScanBackoff)
+
+		listener, err := net.Listen("tcp", listener.Addr().String()) //nolint:noctx
+		if err != nil {
+			t.Error(err)
+			close(publicKeys)
+
+			return
+		}
+
+		publicKeys <- testSSHHostKeyServer(t, listener)
+	}()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+
+	got, err := ansible.ScanSSHHostKeys(ctx, ansible.SSHHostKeyScan{
+		Host:    "127.0.0.1",
+		Port:    port,
+		Backoff: testSSHHostKeyScanBackoff,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := <-publicKeys; !slices.Equal(got, []string{want}) {
+		t.Errorf("expected %v, got %v", []string{want}, got)
+	}
+}
+
+func TestScanSSHHostKeysErrors(t *testing.T) {
+	t.Parallel()
+
+	listener, port := testSSHHostKeyListener(t)
+	testSSHHostKeyServer(t, listener)
+
+	closed, closedPort := testSSHHostKeyListener(t)
+	if err := closed.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		scan     ansible.SSHHostKeyScan
+		want     string
+		deadline bool
+	}{
+		"algorithm": {
+			scan: ansible.SSHHostKeyScan{Host: "127.0.0.1", Port: port, Algorithms: []string{"ssh-dss"}},
+			want: "host key algorithm must be one of",
+		},
+		"no_common_algorithm": {
+			scan: ansible.SSHHostKeyScan{Host: "127.0.0.1", Port: port, Algorithms: []string{gossh.KeyAlgoRSASHA512}},
+			want: "offered none of the host key algorithms",
+		},
+		"timeout": {
+			scan:     ansible.SSHHostKeyScan{Host: "127.0.0.1", Port: closedPort},
+			want:     "failed to connect",
+			deadline: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx, cancel := context.WithTimeout(t.Context(), 10*testSSHHostKeyScanBackoff)
+			defer cancel()
+
+			test.scan.Backoff = testSSHHostKeyScanBackoff
+
+			_, err := ansible.ScanSSHHostKeys(ctx, test.scan)
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Errorf("expected error containing %q, got %v", test.want, err)
+			}
+
+			if test.deadline && !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("expected deadline exceeded, got %v", err)
+			}
+		})
+	}
+}
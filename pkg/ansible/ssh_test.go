@@ -7,8 +7,10 @@ import (
 	"strings"
 	"testing"
 	"testing/iotest"
+	"time"
 
 	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 var errReadFailure = errors.New("read failure")
@@ -114,6 +116,59 @@ func TestKnownHostsLine(t *testing.T) {
 	}
 }
 
+func TestCheckSSHCertificateValidity(t *testing.T) {
+	t.Parallel()
+
+	publicKey, _ := testSSHKeygen(t)
+
+	tests := map[string]struct {
+		validAfter  time.Time
+		validBefore uint64
+		expected    string
+	}{
+		"valid": {
+			validAfter:  time.Now().Add(-time.Hour),
+			validBefore: testSSHCertificateValidFor(time.Hour),
+		},
+		"infinite": {
+			validAfter:  time.Unix(0, 0),
+			validBefore: gossh.CertTimeInfinity,
+		},
+		"expired": {
+			validAfter:  time.Now().Add(-2 * time.Hour),
+			validBefore: testSSHCertificateValidFor(-time.Hour),
+			expected:    "SSH certificate expired at",
+		},
+		"not_yet_valid": {
+			validAfter:  time.Now().Add(time.Hour),
+			validBefore: gossh.CertTimeInfinity,
+			expected:    "SSH certificate is not valid until",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cert, _ := testSSHCertificate(t, publicKey, gossh.UserCert, test.validAfter, test.validBefore)
+
+			err := ansible.CheckSSHCertificateValidity(cert, time.Now())
+
+			if test.expected == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), test.expected) {
+				t.Errorf("expected error containing %q, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
 func TestSSHArgs(t *testing.T) {
 	t.Parallel()
 
@@ -142,3 +197,53 @@ func TestSSHArgs(t *testing.T) {
 		})
 	}
 }
+
+func TestSSHProxyArgs(t *testing.T) {
+	t.Parallel()
+
+	want := "-o StrictHostKeyChecking=yes -o UserKnownHostsFile={{ " + ansible.SSHKnownHostsFileVar + " }}" +
+		` -o ProxyCommand="ssh -F {{ ` + ansible.SSHProxyConfigFileVar + ` }} -o StrictHostKeyChecking=yes -W [%h]:%p ` + ansible.SSHProxyHostAlias + `"`
+
+	if got := ansible.SSHProxyArgs(false); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSSHProxyConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		proxy        ansible.SSHProxy
+		identityFile string
+		expected     string
+	}{
+		"minimal": {
+			proxy: ansible.SSHProxy{Host: "bastion.example.com"},
+			expected: "Host " + ansible.SSHProxyHostAlias + "\n" +
+				"  HostName bastion.example.com\n" +
+				"  Port 22\n" +
+				"  UserKnownHostsFile \"/run/known_hosts\"\n",
+		},
+		"full": {
+			proxy:        ansible.SSHProxy{Host: "10.0.0.1", Port: 2222, User: "jump", PrivateKeyName: "bastion"},
+			identityFile: "/run/private-keys/bastion",
+			expected: "Host " + ansible.SSHProxyHostAlias + "\n" +
+				"  HostName 10.0.0.1\n" +
+				"  Port 2222\n" +
+				"  User jump\n" +
+				"  IdentityFile \"/run/private-keys/bastion\"\n" +
+				"  IdentitiesOnly yes\n" +
+				"  UserKnownHostsFile \"/run/known_hosts\"\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := test.proxy.Config(test.identityFile, "/run/known_hosts"); got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
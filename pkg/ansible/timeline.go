@@ -0,0 +1,149 @@
+package ansible
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// TaskTiming spans every host a task ran on, from the first host to start to
+// the last to finish.
+type TaskTiming struct {
+	Play  string
+	Name  string
+	Start time.Time
+	End   time.Time
+}
+
+func (t TaskTiming) Duration() time.Duration {
+	return t.End.Sub(t.Start)
+}
+
+type PlayTiming struct {
+	Name  string
+	Start time.Time
+	End   time.Time
+	Tasks []TaskTiming
+}
+
+func (p PlayTiming) Duration() time.Duration {
+	return p.End.Sub(p.Start)
+}
+
+// Timeline is built from the start and end timestamps of the job events in
+// the playbook artifact. Tasks without both are left out, as are plays without
+// any such tasks.
+type Timeline struct {
+	Plays []PlayTiming
+}
+
+// WallTime is the time from the first task to start to the last to finish.
+func (t Timeline) WallTime() time.Duration {
+	if len(t.Plays) == 0 {
+		return 0
+	}
+
+	start, end := t.Plays[0].Start, t.Plays[0].End
+	for _, play := range t.Plays[1:] {
+		start = minTime(start, play.Start)
+		end = maxTime(end, play.End)
+	}
+
+	return end.Sub(start)
+}
+
+// SlowestTasks returns at most n tasks, slowest first. Tasks that took as long
+// as each other stay in run order.
+func (t Timeline) SlowestTasks(n int) []TaskTiming {
+	var tasks []TaskTiming
+	for _, play := range t.Plays {
+		tasks = append(tasks, play.Tasks...)
+	}
+
+	slices.SortStableFunc(tasks, func(a, b TaskTiming) int {
+		return cmp.Compare(b.Duration(), a.Duration())
+	})
+
+	return tasks[:min(n, len(tasks))]
+}
+
+// eventTimeLayouts covers ansible-runner versions that omit the time zone,
+// in which case the time is UTC.
+func eventTimeLayouts() []string {
+	return []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04:05.999999999",
+	}
+}
+
+func parseEventTime(value string) (time.Time, bool) {
+	for _, layout := range eventTimeLayouts() {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return parsed, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func parseTimeline(plays []playFormat) Timeline {
+	var timeline Timeline
+
+	for _, playFormat := range plays {
+		play := PlayTiming{Name: playFormat.Name}
+		taskIndex := map[string]int{}
+
+		for _, taskFormat := range playFormat.Tasks {
+			start, startOK := parseEventTime(taskFormat.Start)
+			end, endOK := parseEventTime(taskFormat.End)
+
+			if !startOK || !endOK {
+				continue
+			}
+
+			// The result of each host and loop item is a separate event.
+			key := cmp.Or(taskFormat.TaskUUID, taskFormat.Task)
+
+			index, ok := taskIndex[key]
+			if !ok {
+				taskIndex[key] = len(play.Tasks)
+				play.Tasks = append(play.Tasks, TaskTiming{Play: playFormat.Name, Name: taskFormat.Task, Start: start, End: end})
+
+				continue
+			}
+
+			play.Tasks[index].Start = minTime(play.Tasks[index].Start, start)
+			play.Tasks[index].End = maxTime(play.Tasks[index].End, end)
+		}
+
+		if len(play.Tasks) == 0 {
+			continue
+		}
+
+		play.Start, play.End = play.Tasks[0].Start, play.Tasks[0].End
+		for _, task := range play.Tasks[1:] {
+			play.Start = minTime(play.Start, task.Start)
+			play.End = maxTime(play.End, task.End)
+		}
+
+		timeline.Plays = append(timeline.Plays, play)
+	}
+
+	return timeline
+}
+
+func minTime(a, b time.Time) time.Time {
+	if b.Before(a) {
+		return b
+	}
+
+	return a
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+
+	return a
+}
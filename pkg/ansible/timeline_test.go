@@ -0,0 +1,74 @@
+package ansible_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
+)
+
+func TestPlaybookArtifactTimeline(t *testing.T) {
+	t.Parallel()
+
+	input := `{"status":"successful","stdout":[],"plays":[
+		{"name":"One","tasks":[
+			{"task":"Ping","task_uuid":"t1","host":"a","res":{},"start":"2026-01-01T00:00:00.000000","end":"2026-01-01T00:00:01.000000"},
+			{"task":"Ping","task_uuid":"t1","host":"b","res":{},"start":"2026-01-01T00:00:00.500000","end":"2026-01-01T00:00:02.000000"},
+			{"task":"Copy","task_uuid":"t2","host":"a","res":{},"start":"2026-01-01T00:00:02.000000","end":"2026-01-01T00:00:07.000000"},
+			{"task":"Untimed","task_uuid":"t3","host":"a","res":{}}
+		]},
+		{"name":"Two","tasks":[
+			{"task":"Wait","task_uuid":"t4","host":"a","res":{},"start":"2026-01-01T00:00:08+00:00","end":"2026-01-01T00:00:11+00:00"}
+		]},
+		{"name":"Empty","tasks":[]}
+	]}`
+
+	artifact, err := ansible.ParsePlaybookArtifact([]byte(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	timeline := artifact.Timeline
+
+	if len(timeline.Plays) != 2 {
+		t.Fatalf("want 2 plays, got %+v", timeline.Plays)
+	}
+
+	if got := timeline.Plays[0].Duration(); got != 7*time.Second {
+		t.Errorf("play duration: want 7s, got %s", got)
+	}
+
+	if got := timeline.WallTime(); got != 11*time.Second {
+		t.Errorf("wall time: want 11s, got %s", got)
+	}
+
+	slowest := timeline.SlowestTasks(2)
+
+	var got []string
+	for _, task := range slowest {
+		got = append(got, task.Play+"/"+task.Name+" "+task.Duration().String())
+	}
+
+	want := []string{"One/Copy 5s", "Two/Wait 3s"}
+	if !slices.Equal(got, want) {
+		t.Errorf("slowest tasks: want %q, got %q", want, got)
+	}
+
+	if got := len(timeline.SlowestTasks(10)); got != 3 {
+		t.Errorf("want all 3 timed tasks, got %d", got)
+	}
+}
+
+func TestPlaybookArtifactTimelineEmpty(t *testing.T) {
+	t.Parallel()
+
+	artifact, err := ansible.ParsePlaybookArtifact([]byte(`{"status":"successful"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if artifact.Timeline.WallTime() != 0 || len(artifact.Timeline.SlowestTasks(5)) != 0 {
+		t.Errorf("want empty timeline, got %+v", artifact.Timeline)
+	}
+}
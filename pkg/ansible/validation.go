@@ -1,6 +1,8 @@
 package ansible
 
 import (
+	"bytes"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"strings"
@@ -22,18 +24,42 @@ func ValidateSSHPrivateKey(privateKey string) error {
 
 	_, err := ssh.ParseRawPrivateKey([]byte(privateKey))
 
+	// Encrypted keys are checked along with their passphrase.
 	var passphraseErr *ssh.PassphraseMissingError
-	if errors.As(err, &passphraseErr) {
-		return fmt.Errorf("%w, SSH private key must be unencrypted (no passphrase), %w", ErrValidation, err)
-	}
-
-	if err != nil {
+	if err != nil && !errors.As(err, &passphraseErr) {
 		return fmt.Errorf("%w, SSH private key must be a RSA, DSA, ECDSA, or Ed25519 private key formatted as PKCS#1, PKCS#8, OpenSSL, or OpenSSH", ErrValidation)
 	}
 
 	return nil
 }
 
+// ValidateSSHPrivateKeyPassphrase checks that a passphrase is given for, and
+// only for, an encrypted private key, and that it decrypts the key.
+func ValidateSSHPrivateKeyPassphrase(privateKey string, passphrase string) error {
+	_, err := ssh.ParseRawPrivateKey([]byte(privateKey))
+
+	var passphraseErr *ssh.PassphraseMissingError
+	encrypted := errors.As(err, &passphraseErr)
+
+	switch {
+	case err != nil && !encrypted:
+		return fmt.Errorf("%w, failed to parse SSH private key, %w", ErrValidation, err)
+	case encrypted && passphrase == "":
+		return fmt.Errorf("%w, SSH private key is encrypted, a passphrase is required", ErrValidation)
+	case !encrypted && passphrase != "":
+		return fmt.Errorf("%w, SSH private key is not encrypted, a passphrase must not be set", ErrValidation)
+	case !encrypted:
+		return nil
+	}
+
+	_, err = parseSSHPrivateKey(privateKey, passphrase)
+	if errors.Is(err, x509.IncorrectPasswordError) {
+		return fmt.Errorf("%w, SSH private key passphrase is incorrect", ErrValidation)
+	}
+
+	return err
+}
+
 func ValidateSSHPrivateKeyName(privateKeyName string) error {
 	if len(privateKeyName) == 0 {
 		return fmt.Errorf("%w, SSH private key name cannot be empty", ErrValidation)
@@ -52,12 +78,44 @@ func ValidateSSHPrivateKeyName(privateKeyName string) error {
 	return nil
 }
 
+func ValidateInventoryName(inventoryName string) error {
+	if len(inventoryName) == 0 {
+		return fmt.Errorf("%w, inventory name cannot be empty", ErrValidation)
+	}
+
+	if strings.HasPrefix(inventoryName, ".") {
+		return fmt.Errorf("%w, inventory name cannot start with a dot", ErrValidation)
+	}
+
+	for _, character := range inventoryName {
+		if !unicode.IsLetter(character) && !unicode.IsDigit(character) && !strings.ContainsRune("-_.", character) {
+			return fmt.Errorf("%w, inventory name can only contain letters (A-Z, a-z), numbers (0-9), dashes (-), underscores (_), and dots (.)", ErrValidation)
+		}
+	}
+
+	return nil
+}
+
+func ValidateVaultID(vaultID string) error {
+	if len(vaultID) == 0 {
+		return fmt.Errorf("%w, vault ID cannot be empty", ErrValidation)
+	}
+
+	for _, character := range vaultID {
+		if !unicode.IsLetter(character) && !unicode.IsDigit(character) && character != '-' && character != '_' {
+			return fmt.Errorf("%w, vault ID can only contain letters (A-Z, a-z), numbers (0-9), dashes (-), and underscores (_)", ErrValidation)
+		}
+	}
+
+	return nil
+}
+
 func ValidateSSHKnownHost(knownHost string) error {
 	if len(knownHost) == 0 {
 		return fmt.Errorf("%w, SSH known host must not be empty", ErrValidation)
 	}
 
-	_, _, _, _, rest, err := ssh.ParseKnownHosts([]byte(knownHost)) //nolint:dogsled
+	marker, _, publicKey, _, rest, err := ssh.ParseKnownHosts([]byte(knownHost))
 	if err != nil {
 		return fmt.Errorf("%w, failed to parse SSH known host, %w", ErrValidation, err)
 	}
@@ -66,6 +124,40 @@ func ValidateSSHKnownHost(knownHost string) error {
 		return fmt.Errorf("%w, must not include multiple SSH known host entries or additional data", ErrValidation)
 	}
 
+	if marker != "" && marker != sshCertAuthorityMarker && marker != sshRevokedMarker {
+		return fmt.Errorf("%w, SSH known host marker must be '@%s' or '@%s'", ErrValidation, sshCertAuthorityMarker, sshRevokedMarker)
+	}
+
+	if _, ok := publicKey.(*ssh.Certificate); ok && marker == sshCertAuthorityMarker {
+		return fmt.Errorf("%w, SSH known host '@%s' entry must contain the public key of the certificate authority, not a certificate", ErrValidation, sshCertAuthorityMarker)
+	}
+
+	return nil
+}
+
+func ValidateSSHCertificate(certificate string) error {
+	_, err := parseSSHCertificate(certificate)
+
+	return err
+}
+
+// ValidateSSHCertificateKey checks that the certificate was issued for the
+// public key of the private key.
+func ValidateSSHCertificateKey(privateKey string, passphrase string, certificate string) error {
+	cert, err := parseSSHCertificate(certificate)
+	if err != nil {
+		return err
+	}
+
+	signer, err := sshSigner(privateKey, passphrase)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(cert.Key.Marshal(), signer.PublicKey().Marshal()) {
+		return fmt.Errorf("%w, SSH certificate was not issued for the public key of the private key", ErrValidation)
+	}
+
 	return nil
 }
 
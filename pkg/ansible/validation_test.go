@@ -3,10 +3,13 @@ package ansible_test
 import (
 	"crypto"
 	"crypto/ed25519"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/pem"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/marshallford/terraform-provider-ansible/pkg/ansible"
 	gossh "golang.org/x/crypto/ssh"
@@ -15,12 +18,24 @@ import (
 func testSSHKeygen(t *testing.T) (string, string) {
 	t.Helper()
 
+	return testSSHKeygenWithPassphrase(t, "")
+}
+
+func testSSHKeygenWithPassphrase(t *testing.T, passphrase string) (string, string) {
+	t.Helper()
+
 	pub, priv, err := ed25519.GenerateKey(nil)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	privateKey, err := gossh.MarshalPrivateKey(crypto.PrivateKey(priv), "")
+	var privateKey *pem.Block
+	if passphrase == "" {
+		privateKey, err = gossh.MarshalPrivateKey(crypto.PrivateKey(priv), "")
+	} else {
+		privateKey, err = gossh.MarshalPrivateKeyWithPassphrase(crypto.PrivateKey(priv), "", []byte(passphrase))
+	}
+
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -33,10 +48,51 @@ func testSSHKeygen(t *testing.T) (string, string) {
 	return fmt.Sprintf("ssh-ed25519 %s", base64.StdEncoding.EncodeToString(publicKey.Marshal())), string(pem.EncodeToMemory(privateKey))
 }
 
+// testSSHCertificate signs the public key with a throwaway certificate
+// authority and returns the certificate and the public key of the authority.
+func testSSHCertificate(t *testing.T, publicKey string, certType uint32, validAfter time.Time, validBefore uint64) (string, string) {
+	t.Helper()
+
+	key, _, _, _, err := gossh.ParseAuthorizedKey([]byte(publicKey)) //nolint:dogsled
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, caPrivateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caSigner, err := gossh.NewSignerFromKey(caPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert := &gossh.Certificate{
+		Key:             key,
+		CertType:        certType,
+		KeyId:           "test",
+		ValidPrincipals: []string{"test"},
+		ValidAfter:      uint64(validAfter.Unix()), //nolint:gosec
+		ValidBefore:     validBefore,
+	}
+
+	if err := cert.SignCert(rand.Reader, caSigner); err != nil {
+		t.Fatal(err)
+	}
+
+	return strings.TrimSpace(string(gossh.MarshalAuthorizedKey(cert))), strings.TrimSpace(string(gossh.MarshalAuthorizedKey(caSigner.PublicKey())))
+}
+
+func testSSHCertificateValidFor(duration time.Duration) uint64 {
+	return uint64(time.Now().Add(duration).Unix()) //nolint:gosec
+}
+
 func TestValidateSSHPrivateKey(t *testing.T) {
 	t.Parallel()
 
 	_, validKey := testSSHKeygen(t)
+	_, encryptedKey := testSSHKeygenWithPassphrase(t, "secret")
 
 	tests := map[string]struct {
 		input     string
@@ -45,6 +101,9 @@ func TestValidateSSHPrivateKey(t *testing.T) {
 		"valid": {
 			input: validKey,
 		},
+		"encrypted": {
+			input: encryptedKey,
+		},
 		"empty": {
 			input:     "",
 			expectErr: true,
@@ -76,6 +135,143 @@ func TestValidateSSHPrivateKey(t *testing.T) {
 	}
 }
 
+func TestValidateSSHCertificate(t *testing.T) {
+	t.Parallel()
+
+	publicKey, _ := testSSHKeygen(t)
+	userCert, _ := testSSHCertificate(t, publicKey, gossh.UserCert, time.Now(), gossh.CertTimeInfinity)
+	hostCert, _ := testSSHCertificate(t, publicKey, gossh.HostCert, time.Now(), gossh.CertTimeInfinity)
+
+	tests := map[string]struct {
+		input     string
+		expectErr bool
+	}{
+		"valid": {
+			input: userCert,
+		},
+		"empty": {
+			input:     "",
+			expectErr: true,
+		},
+		"public_key": {
+			input:     publicKey,
+			expectErr: true,
+		},
+		"host_certificate": {
+			input:     hostCert,
+			expectErr: true,
+		},
+		"multiple_certificates": {
+			input:     fmt.Sprintf("%s\n%s", userCert, userCert),
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ansible.ValidateSSHCertificate(test.input)
+
+			if test.expectErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateSSHCertificateKey(t *testing.T) {
+	t.Parallel()
+
+	publicKey, privateKey := testSSHKeygen(t)
+	otherPublicKey, _ := testSSHKeygen(t)
+	cert, _ := testSSHCertificate(t, publicKey, gossh.UserCert, time.Now(), gossh.CertTimeInfinity)
+	otherCert, _ := testSSHCertificate(t, otherPublicKey, gossh.UserCert, time.Now(), gossh.CertTimeInfinity)
+
+	encryptedPublicKey, encryptedPrivateKey := testSSHKeygenWithPassphrase(t, "secret")
+	encryptedCert, _ := testSSHCertificate(t, encryptedPublicKey, gossh.UserCert, time.Now(), gossh.CertTimeInfinity)
+
+	if err := ansible.ValidateSSHCertificateKey(privateKey, "", cert); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if err := ansible.ValidateSSHCertificateKey(privateKey, "", otherCert); err == nil {
+		t.Error("expected error for certificate of another key, got nil")
+	}
+
+	if err := ansible.ValidateSSHCertificateKey(encryptedPrivateKey, "secret", encryptedCert); err != nil {
+		t.Errorf("unexpected error for encrypted key: %v", err)
+	}
+}
+
+func TestValidateSSHPrivateKeyPassphrase(t *testing.T) {
+	t.Parallel()
+
+	_, privateKey := testSSHKeygen(t)
+	_, encryptedKey := testSSHKeygenWithPassphrase(t, "secret")
+
+	tests := map[string]struct {
+		privateKey string
+		passphrase string
+		want       string
+	}{
+		"unencrypted": {
+			privateKey: privateKey,
+		},
+		"encrypted": {
+			privateKey: encryptedKey,
+			passphrase: "secret",
+		},
+		"missing": {
+			privateKey: encryptedKey,
+			want:       "a passphrase is required",
+		},
+		"incorrect": {
+			privateKey: encryptedKey,
+			passphrase: "wrong",
+			want:       "passphrase is incorrect",
+		},
+		"unexpected": {
+			privateKey: privateKey,
+			passphrase: "secret",
+			want:       "a passphrase must not be set",
+		},
+		"invalid": {
+			privateKey: "not-a-key",
+			passphrase: "secret",
+			want:       "failed to parse SSH private key",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ansible.ValidateSSHPrivateKeyPassphrase(test.privateKey, test.passphrase)
+
+			if test.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), test.want) {
+				t.Fatalf("expected error containing %q, got %v", test.want, err)
+			}
+		})
+	}
+}
+
 func TestValidateSSHPrivateKeyName(t *testing.T) {
 	t.Parallel()
 
@@ -125,10 +321,104 @@ func TestValidateSSHPrivateKeyName(t *testing.T) {
 	}
 }
 
+func TestValidateInventoryName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input     string
+		expectErr bool
+	}{
+		"valid": {
+			input: "static-hosts",
+		},
+		"plugin_config": {
+			input: "prod.aws_ec2.yml",
+		},
+		"empty": {
+			input:     "",
+			expectErr: true,
+		},
+		"hidden": {
+			input:     ".hosts",
+			expectErr: true,
+		},
+		"path": {
+			input:     "group_vars/all.yml",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ansible.ValidateInventoryName(test.input)
+
+			if test.expectErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateVaultID(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		input     string
+		expectErr bool
+	}{
+		"valid": {
+			input: "prod_east-1",
+		},
+		"empty": {
+			input:     "",
+			expectErr: true,
+		},
+		"separator": {
+			input:     "prod@file",
+			expectErr: true,
+		},
+		"path": {
+			input:     "../prod",
+			expectErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := ansible.ValidateVaultID(test.input)
+
+			if test.expectErr {
+				if err == nil {
+					t.Fatal("expected error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
 func TestValidateSSHKnownHost(t *testing.T) {
 	t.Parallel()
 
 	publicKey, _ := testSSHKeygen(t)
+	cert, caPublicKey := testSSHCertificate(t, publicKey, gossh.HostCert, time.Now(), gossh.CertTimeInfinity)
 
 	tests := map[string]struct {
 		input     string
@@ -149,6 +439,20 @@ func TestValidateSSHKnownHost(t *testing.T) {
 			input:     fmt.Sprintf("host1 %s\nhost2 %s", publicKey, publicKey),
 			expectErr: true,
 		},
+		"cert_authority": {
+			input: fmt.Sprintf("@cert-authority *.example.com %s", caPublicKey),
+		},
+		"revoked": {
+			input: fmt.Sprintf("@revoked host1 %s", publicKey),
+		},
+		"unknown_marker": {
+			input:     fmt.Sprintf("@trusted host1 %s", publicKey),
+			expectErr: true,
+		},
+		"cert_authority_certificate": {
+			input:     fmt.Sprintf("@cert-authority *.example.com %s", cert),
+			expectErr: true,
+		},
 	}
 
 	for name, test := range tests {